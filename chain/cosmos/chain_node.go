@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/sha256"
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -29,6 +30,7 @@ import (
 	authTx "github.com/cosmos/cosmos-sdk/x/auth/tx"
 	paramsutils "github.com/cosmos/cosmos-sdk/x/params/client/utils"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	host "github.com/cosmos/ibc-go/v8/modules/core/24-host"
 	volumetypes "github.com/docker/docker/api/types/volume"
 	dockerclient "github.com/docker/docker/client"
 	"github.com/docker/go-connections/nat"
@@ -1059,6 +1061,65 @@ func (tn *ChainNode) GetModuleAccount(ctx context.Context, moduleName string) (Q
 	return queryRes, nil
 }
 
+// QueryNextSequences returns the sequences of the next packets sent, received and acknowledged on the given channel end.
+func (tn *ChainNode) QueryNextSequences(ctx context.Context, portID, channelID string) (NextSequences, error) {
+	var seqs NextSequences
+	for _, q := range []struct {
+		query string
+		seq   *uint64
+	}{
+		{"next-sequence-send", &seqs.Send},
+		{"next-sequence-receive", &seqs.Recv},
+	} {
+		stdout, _, err := tn.ExecQuery(ctx, "ibc", "channel", q.query, portID, channelID)
+		if err != nil {
+			return NextSequences{}, err
+		}
+		if *q.seq, err = ParseNextSequenceOutput(stdout); err != nil {
+			return NextSequences{}, err
+		}
+	}
+
+	// The CLI has no query for the next sequence to be acknowledged, so it is read from the ibc store.
+	res, err := tn.Client.ABCIQuery(ctx, "/store/ibc/key", host.NextSequenceAckKey(portID, channelID))
+	if err != nil {
+		return NextSequences{}, fmt.Errorf("failed to query next sequence ack: %w", err)
+	}
+	if res.Response.Code != 0 {
+		return NextSequences{}, fmt.Errorf("failed to query next sequence ack: %s", res.Response.Log)
+	}
+	if seqs.Ack, err = ParseNextSequenceAck(res.Response.Value); err != nil {
+		return NextSequences{}, err
+	}
+	return seqs, nil
+}
+
+// ParseNextSequenceAck decodes the next sequence ack of a channel end as stored by ibc-go,
+// a big endian uint64.
+func ParseNextSequenceAck(value []byte) (uint64, error) {
+	if len(value) != 8 {
+		return 0, fmt.Errorf("invalid next sequence ack %x: not found or not 8 bytes", value)
+	}
+	return binary.BigEndian.Uint64(value), nil
+}
+
+// ParseNextSequenceOutput extracts the sequence from the output of the next-sequence-send
+// or next-sequence-receive channel query.
+func ParseNextSequenceOutput(stdout []byte) (uint64, error) {
+	var res QueryNextSequenceResponse
+	if err := json.Unmarshal(stdout, &res); err != nil {
+		return 0, fmt.Errorf("failed to parse next sequence: %w", err)
+	}
+	seq := res.NextSequenceSend
+	if seq == "" {
+		seq = res.NextSequenceReceive
+	}
+	if seq == "" {
+		return 0, fmt.Errorf("no next sequence in output: %s", stdout)
+	}
+	return strconv.ParseUint(seq, 10, 64)
+}
+
 // VoteOnProposal submits a vote for the specified proposal.
 func (tn *ChainNode) VoteOnProposal(ctx context.Context, keyName string, proposalID string, vote string) error {
 	_, err := tn.ExecTx(ctx, keyName,
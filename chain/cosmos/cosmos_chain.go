@@ -486,6 +486,11 @@ func (c *CosmosChain) QueryParam(ctx context.Context, subspace, key string) (*Pa
 	return c.getFullNode().QueryParam(ctx, subspace, key)
 }
 
+// QueryNextSequences returns the sequences of the next packets sent, received and acknowledged on the given channel end.
+func (c *CosmosChain) QueryNextSequences(ctx context.Context, portID, channelID string) (NextSequences, error) {
+	return c.getFullNode().QueryNextSequences(ctx, portID, channelID)
+}
+
 // QueryBankMetadata returns the metadata of a given token denomination.
 func (c *CosmosChain) QueryBankMetadata(ctx context.Context, denom string) (*BankMetaData, error) {
 	return c.getFullNode().QueryBankMetadata(ctx, denom)
@@ -27,3 +27,29 @@ func TestCondenseMoniker_Short(t *testing.T) {
 	const m = "my_moniker"
 	require.Equal(t, m, cosmos.CondenseMoniker(m))
 }
+
+func TestParseNextSequenceOutput(t *testing.T) {
+	seq, err := cosmos.ParseNextSequenceOutput([]byte(`{"next_sequence_send":"7","proof":null,"proof_height":{"revision_number":"0","revision_height":"42"}}`))
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), seq)
+
+	seq, err = cosmos.ParseNextSequenceOutput([]byte(`{"next_sequence_receive":"3","proof":null,"proof_height":{"revision_number":"0","revision_height":"42"}}`))
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), seq)
+
+	_, err = cosmos.ParseNextSequenceOutput([]byte(`{"proof":null}`))
+	require.ErrorContains(t, err, "no next sequence")
+
+	_, err = cosmos.ParseNextSequenceOutput([]byte("garbage"))
+	require.Error(t, err)
+}
+
+func TestParseNextSequenceAck(t *testing.T) {
+	seq, err := cosmos.ParseNextSequenceAck([]byte{0, 0, 0, 0, 0, 0, 0, 5})
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), seq)
+
+	// An empty value means the channel end does not exist.
+	_, err = cosmos.ParseNextSequenceAck(nil)
+	require.ErrorContains(t, err, "not found")
+}
@@ -165,3 +165,21 @@ type QueryModuleAccountResponse struct {
 		Name string `json:"name"`
 	} `json:"account"`
 }
+
+// NextSequences are the sequences of the next packets of a channel end.
+type NextSequences struct {
+	// Send is the sequence of the next packet sent on the channel.
+	Send uint64
+	// Recv is the sequence of the next packet expected to be received on an ordered channel.
+	// Unordered channels accept packets in any order and always report 1.
+	Recv uint64
+	// Ack is the sequence of the next packet expected to be acknowledged on an ordered channel.
+	// Unordered channels accept acknowledgements in any order and always report 1.
+	Ack uint64
+}
+
+// QueryNextSequenceResponse is the output of the next-sequence-send and next-sequence-receive channel queries.
+type QueryNextSequenceResponse struct {
+	NextSequenceSend    string `json:"next_sequence_send"`
+	NextSequenceReceive string `json:"next_sequence_receive"`
+}
@@ -0,0 +1,84 @@
+package ibc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v8"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/relayer/hermes"
+	"github.com/strangelove-ventures/interchaintest/v8/testreporter"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestRelayerAddChain verifies that a chain can be added to a running relayer,
+// and that the relayer keeps running with the new chain in its config.
+func TestRelayerAddChain(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", ChainName: "gaia-1", Version: "v7.0.0", ChainConfig: ibc.ChainConfig{ChainID: "gaia-1", GasPrices: "0.0uatom"}},
+		{Name: "gaia", ChainName: "gaia-2", Version: "v7.0.0", ChainConfig: ibc.ChainConfig{ChainID: "gaia-2", GasPrices: "0.0uatom"}},
+		{Name: "gaia", ChainName: "gaia-3", Version: "v7.0.0", ChainConfig: ibc.ChainConfig{ChainID: "gaia-3", GasPrices: "0.0uatom"}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia1, gaia2, gaia3 := chains[0], chains[1], chains[2]
+
+	client, network := interchaintest.DockerSetup(t)
+	r := interchaintest.NewBuiltinRelayerFactory(ibc.Hermes, zaptest.NewLogger(t)).Build(t, client, network).(*hermes.Relayer)
+
+	// gaia-3 is not linked, so it is started but not added to the relayer by Build.
+	const ibcPath = "gaia-gaia-add-chain"
+	ic := interchaintest.NewInterchain().
+		AddChain(gaia1).
+		AddChain(gaia2).
+		AddChain(gaia3).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  gaia1,
+			Chain2:  gaia2,
+			Relayer: r,
+			Path:    ibcPath,
+		})
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	require.NoError(t, r.StartRelayer(ctx, eRep, ibcPath))
+	t.Cleanup(func() {
+		_ = r.StopRelayer(ctx, eRep)
+	})
+
+	relayerUser := interchaintest.GetAndFundTestUsers(t, ctx, "relayer", 10_000_000, gaia3)[0]
+	gaia3Cfg := gaia3.Config()
+	require.NoError(t, r.AddChain(ctx, eRep, gaia3Cfg, "relayer", gaia3.GetRPCAddress(), gaia3.GetGRPCAddress(), relayerUser.Mnemonic()))
+
+	require.Contains(t, r.ConfiguredChains(), "gaia-3")
+	config, err := r.ReadFileFromHomeDir(ctx, ".hermes/config.toml")
+	require.NoError(t, err)
+	require.Contains(t, string(config), "gaia-3")
+
+	running, err := r.IsRunning(ctx)
+	require.NoError(t, err)
+	require.True(t, running)
+
+	require.ErrorContains(t, r.AddChain(ctx, eRep, gaia3Cfg, "relayer", gaia3.GetRPCAddress(), gaia3.GetGRPCAddress(), ""), "already configured")
+}
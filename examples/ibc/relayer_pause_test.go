@@ -0,0 +1,117 @@
+package ibc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cosmossdk.io/math"
+	transfertypes "github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
+	"github.com/strangelove-ventures/interchaintest/v8"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v8/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestRelayerPauseResume verifies that no packets are relayed while the relayer is paused,
+// and that relaying continues once the relayer is resumed.
+func TestRelayerPauseResume(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", ChainName: "gaia-1", Version: "v7.0.0", ChainConfig: ibc.ChainConfig{ChainID: "gaia-1", GasPrices: "0.0uatom"}},
+		{Name: "gaia", ChainName: "gaia-2", Version: "v7.0.0", ChainConfig: ibc.ChainConfig{ChainID: "gaia-2", GasPrices: "0.0uatom"}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia1, gaia2 := chains[0], chains[1]
+
+	client, network := interchaintest.DockerSetup(t)
+	r := interchaintest.NewBuiltinRelayerFactory(ibc.Hermes, zaptest.NewLogger(t)).Build(t, client, network)
+
+	const ibcPath = "gaia-gaia-pause"
+	ic := interchaintest.NewInterchain().
+		AddChain(gaia1).
+		AddChain(gaia2).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  gaia1,
+			Chain2:  gaia2,
+			Relayer: r,
+			Path:    ibcPath,
+		})
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	// Pausing or resuming a relayer that was never started is an error.
+	require.Error(t, r.PauseRelayer(ctx))
+	require.Error(t, r.ResumeRelayer(ctx))
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "default", 10_000_000, gaia1, gaia2)
+	gaia1User, gaia2User := users[0], users[1]
+
+	channels, err := r.GetChannels(ctx, eRep, gaia1.Config().ChainID)
+	require.NoError(t, err)
+	require.Len(t, channels, 1)
+	channel := channels[0]
+
+	require.NoError(t, r.StartRelayer(ctx, eRep, ibcPath))
+	t.Cleanup(func() {
+		_ = r.StopRelayer(ctx, eRep)
+	})
+
+	// Resuming a relayer that is not paused is an error.
+	require.Error(t, r.ResumeRelayer(ctx))
+
+	require.NoError(t, r.PauseRelayer(ctx))
+
+	amountToSend := math.NewInt(1_000)
+	_, err = gaia1.SendIBCTransfer(ctx, channel.ChannelID, gaia1User.KeyName(), ibc.WalletAmount{
+		Address: gaia2User.FormattedAddress(),
+		Denom:   gaia1.Config().Denom,
+		Amount:  amountToSend,
+	}, ibc.TransferOptions{})
+	require.NoError(t, err)
+
+	dstIbcDenom := transfertypes.ParseDenomTrace(
+		transfertypes.GetPrefixedDenom(channel.Counterparty.PortID, channel.Counterparty.ChannelID, gaia1.Config().Denom),
+	).IBCDenom()
+
+	// While paused, the transfer must not arrive on the counterparty chain.
+	require.NoError(t, testutil.WaitForBlocks(ctx, 10, gaia1, gaia2))
+	bal, err := gaia2.GetBalance(ctx, gaia2User.FormattedAddress(), dstIbcDenom)
+	require.NoError(t, err)
+	require.True(t, bal.IsZero())
+
+	require.NoError(t, r.ResumeRelayer(ctx))
+
+	require.NoError(t, testutil.WaitForCondition(
+		2*time.Minute, time.Second,
+		func() (bool, error) {
+			bal, err := gaia2.GetBalance(ctx, gaia2User.FormattedAddress(), dstIbcDenom)
+			if err != nil {
+				return false, err
+			}
+			return bal.Equal(amountToSend), nil
+		},
+	))
+}
@@ -0,0 +1,23 @@
+package ibc
+
+import (
+	transfertypes "github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
+)
+
+// IBCDenom returns the "ibc/<hash>" denom that baseDenom is held as on the destination chain
+// after an ICS-20 transfer is received on the given port and channel.
+//
+// The port and channel are those of the receiving end, i.e. the counterparty of the channel the transfer was sent on.
+func IBCDenom(portID, channelID, baseDenom string) string {
+	return transfertypes.ParseDenomTrace(transfertypes.GetPrefixedDenom(portID, channelID, baseDenom)).IBCDenom()
+}
+
+// EscrowAddress returns the address holding the native tokens sent out over the given port and channel
+// by ICS-20 transfers, as derived by the transfer module.
+//
+// The address is encoded with the account prefix of the SDK config, "cosmos" unless changed, e.g. through
+// cosmos.SetSDKConfig. The derivation does not depend on the chain, so the address can be re-encoded
+// with another prefix using sdk.Bech32ifyAddressBytes.
+func EscrowAddress(portID, channelID string) string {
+	return transfertypes.GetEscrowAddress(portID, channelID).String()
+}
@@ -0,0 +1,22 @@
+package ibc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIBCDenom(t *testing.T) {
+	// ATOM as held on Osmosis over transfer/channel-0.
+	require.Equal(t, "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2", IBCDenom("transfer", "channel-0", "uatom"))
+
+	// Multi-hop traces hash the full path.
+	require.NotEqual(t, IBCDenom("transfer", "channel-0", "uatom"), IBCDenom("transfer", "channel-0", "transfer/channel-1/uatom"))
+}
+
+func TestEscrowAddress(t *testing.T) {
+	// The escrow account of transfer/channel-0 on the Cosmos Hub.
+	require.Equal(t, "cosmos1a53udazy8ayufvy0s434pfwjcedzqv34kvz9tw", EscrowAddress("transfer", "channel-0"))
+
+	require.NotEqual(t, EscrowAddress("transfer", "channel-0"), EscrowAddress("transfer", "channel-1"))
+}
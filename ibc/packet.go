@@ -1,6 +1,7 @@
 package ibc
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
@@ -76,6 +77,28 @@ func (ack PacketAcknowledgement) Validate() error {
 	return multierr.Append(err, ack.Packet.Validate())
 }
 
+// ErrorAck decodes the acknowledgement and reports whether it is an error acknowledgement,
+// i.e. whether the counterparty chain rejected the packet, along with the error message.
+// Only the standard ICS-4 JSON acknowledgement format, {"result": ...} or {"error": ...}, is recognized;
+// an error is returned for any other acknowledgement.
+func (ack PacketAcknowledgement) ErrorAck() (string, bool, error) {
+	var decoded struct {
+		Result json.RawMessage `json:"result"`
+		Error  *string         `json:"error"`
+	}
+	if err := json.Unmarshal(ack.Acknowledgement, &decoded); err != nil {
+		return "", false, fmt.Errorf("decoding acknowledgement of packet %d: %w", ack.Packet.Sequence, err)
+	}
+	switch {
+	case decoded.Error != nil:
+		return *decoded.Error, true, nil
+	case decoded.Result != nil:
+		return "", false, nil
+	default:
+		return "", false, fmt.Errorf("acknowledgement of packet %d has neither result nor error: %s", ack.Packet.Sequence, ack.Acknowledgement)
+	}
+}
+
 // PacketTimeout signals a packet was not processed by the counterparty chain.
 // Indicates the sending chain should undo or rollback state.
 // Timeout conditions are block height and timestamp.
@@ -137,3 +137,26 @@ func TestPacketTimeout_Validate(t *testing.T) {
 	timeout.Packet = validPacket()
 	require.NoError(t, timeout.Validate())
 }
+
+func TestPacketAcknowledgement_ErrorAck(t *testing.T) {
+	ack := PacketAcknowledgement{Packet: validPacket()}
+
+	ack.Acknowledgement = []byte(`{"error":"ABCI code: 1: error handling packet: see events for details"}`)
+	msg, isErr, err := ack.ErrorAck()
+	require.NoError(t, err)
+	require.True(t, isErr)
+	require.Equal(t, "ABCI code: 1: error handling packet: see events for details", msg)
+
+	ack.Acknowledgement = []byte(`{"result":"AQ=="}`)
+	_, isErr, err = ack.ErrorAck()
+	require.NoError(t, err)
+	require.False(t, isErr)
+
+	ack.Acknowledgement = []byte(`{}`)
+	_, _, err = ack.ErrorAck()
+	require.ErrorContains(t, err, "neither result nor error")
+
+	ack.Acknowledgement = []byte{0x01}
+	_, _, err = ack.ErrorAck()
+	require.Error(t, err)
+}
@@ -2,7 +2,11 @@ package ibc
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	chantypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
@@ -105,6 +109,212 @@ type Relayer interface {
 	SetClientContractHash(ctx context.Context, rep RelayerExecReporter, cfg ChainConfig, hash string) error
 }
 
+// ErrConnectionNotFound is returned when a connection with the requested ID does not exist on a chain.
+var ErrConnectionNotFound = errors.New("connection not found")
+
+// GetConnection returns the connection with the given connection ID on the specified chain.
+// If the connection does not exist, an error wrapping ErrConnectionNotFound is returned.
+func GetConnection(ctx context.Context, r Relayer, rep RelayerExecReporter, chainID, connectionID string) (ConnectionOutput, error) {
+	connections, err := r.GetConnections(ctx, rep, chainID)
+	if err != nil {
+		return ConnectionOutput{}, fmt.Errorf("failed to get connections on %s: %w", chainID, err)
+	}
+
+	conn, ok := connections.Find(connectionID)
+	if !ok {
+		return ConnectionOutput{}, fmt.Errorf("%s on %s: %w", connectionID, chainID, ErrConnectionNotFound)
+	}
+	return *conn, nil
+}
+
+// WaitForConnectionOpen polls the relayer until the connections with the given connection IDs on the specified chain
+// have completed their handshake, i.e. progressed through INIT or TRYOPEN to OPEN, or until the timeout elapses.
+// On timeout, the error lists each connection that is not open with the last state it was seen in,
+// or that it was never found.
+func WaitForConnectionOpen(ctx context.Context, r Relayer, rep RelayerExecReporter, chainID string, timeout time.Duration, connectionIDs ...string) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// last holds the connections of the last successful query, which are reported if the timeout elapses
+	// while querying.
+	var last ConnectionOutputs
+	queried := false
+	for {
+		connections, err := r.GetConnections(ctx, rep, chainID)
+		switch {
+		case err == nil:
+			last, queried = connections, true
+		case ctx.Err() == nil:
+			return fmt.Errorf("failed to get connections on %s: %w", chainID, err)
+		}
+
+		var notOpen []string
+		for _, id := range connectionIDs {
+			conn, ok := last.Find(id)
+			switch {
+			case !queried:
+				notOpen = append(notOpen, fmt.Sprintf("%s (last state %q)", id, "unknown"))
+			case !ok:
+				notOpen = append(notOpen, fmt.Sprintf("%s (last state %q)", id, "not found"))
+			case !conn.IsOpen():
+				notOpen = append(notOpen, fmt.Sprintf("%s (last state %q)", id, conn.State))
+			}
+		}
+		if err == nil && len(notOpen) == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("connections on %s not open after %s: %s: %w", chainID, timeout, strings.Join(notOpen, ", "), ctx.Err())
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// ErrChannelNotFound is returned when a channel with the requested ID does not exist on a chain.
+var ErrChannelNotFound = errors.New("channel not found")
+
+// GetChannel returns the channel with the given channel ID on the specified chain.
+// If the channel does not exist, an error wrapping ErrChannelNotFound is returned.
+func GetChannel(ctx context.Context, r Relayer, rep RelayerExecReporter, chainID, channelID string) (*ChannelOutput, error) {
+	channels, err := r.GetChannels(ctx, rep, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get channels on %s: %w", chainID, err)
+	}
+
+	for _, ch := range channels {
+		if ch.ChannelID == channelID {
+			return &ch, nil
+		}
+	}
+	return nil, fmt.Errorf("%s on %s: %w", channelID, chainID, ErrChannelNotFound)
+}
+
+// AssertCounterparty returns an error unless the channel with the given channel ID on the specified chain
+// has the expected counterparty port and channel.
+func AssertCounterparty(ctx context.Context, r Relayer, rep RelayerExecReporter, chainID, channelID, expectedPort, expectedChannel string) error {
+	ch, err := GetChannel(ctx, r, rep, chainID, channelID)
+	if err != nil {
+		return err
+	}
+	if ch.Counterparty.PortID != expectedPort || ch.Counterparty.ChannelID != expectedChannel {
+		return fmt.Errorf("channel %s on %s has counterparty %s/%s, expected %s/%s",
+			channelID, chainID, ch.Counterparty.PortID, ch.Counterparty.ChannelID, expectedPort, expectedChannel)
+	}
+	return nil
+}
+
+// AssertClientCounts returns an error unless the clients on the specified chain match the expected number of clients
+// of each type, e.g. {"07-tendermint": 1}, to catch stray or duplicate clients such as those created when a path is
+// linked twice. Clients of a type missing from expected are unexpected. The type of a client is taken from its ID.
+func AssertClientCounts(ctx context.Context, r Relayer, rep RelayerExecReporter, chainID string, expected map[string]int) error {
+	clients, err := r.GetClients(ctx, rep, chainID)
+	if err != nil {
+		return fmt.Errorf("failed to get clients on %s: %w", chainID, err)
+	}
+
+	byType := map[string][]string{}
+	for _, c := range clients {
+		clientType := c.ClientID
+		// Client IDs are the type followed by a counter, except for the localhost client.
+		if i := strings.LastIndex(clientType, "-"); i > 0 {
+			if _, err := strconv.ParseUint(clientType[i+1:], 10, 64); err == nil {
+				clientType = clientType[:i]
+			}
+		}
+		byType[clientType] = append(byType[clientType], c.ClientID)
+	}
+
+	types := make([]string, 0, len(byType)+len(expected))
+	for clientType := range byType {
+		types = append(types, clientType)
+	}
+	for clientType := range expected {
+		if _, ok := byType[clientType]; !ok {
+			types = append(types, clientType)
+		}
+	}
+	sort.Strings(types)
+
+	var mismatches []string
+	for _, clientType := range types {
+		ids := byType[clientType]
+		if len(ids) != expected[clientType] {
+			mismatches = append(mismatches, fmt.Sprintf("%d %s clients [%s], expected %d",
+				len(ids), clientType, strings.Join(ids, " "), expected[clientType]))
+		}
+	}
+	if len(mismatches) > 0 {
+		return fmt.Errorf("unexpected clients on %s: %s", chainID, strings.Join(mismatches, "; "))
+	}
+	return nil
+}
+
+// WaitForChannelClose polls the relayer until the channel with the given channel ID on the specified chain
+// has been closed, or until the timeout elapses.
+func WaitForChannelClose(ctx context.Context, r Relayer, rep RelayerExecReporter, chainID, channelID string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var lastState string
+	for {
+		ch, err := GetChannel(ctx, r, rep, chainID, channelID)
+		switch {
+		case err == nil && ch.IsClosed():
+			return nil
+		case err == nil:
+			lastState = ch.State
+		case ctx.Err() == nil && !errors.Is(err, ErrChannelNotFound):
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("channel %s on %s not closed after %s (last state %q): %w", channelID, chainID, timeout, lastState, ctx.Err())
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// StartRelayers starts each of the relayers on the given paths, in order, waiting for stagger between consecutive
+// starts so that relayers launched together do not overwhelm the chain RPC endpoints with their initial queries.
+// A zero stagger starts the relayers back to back. If a relayer fails to start, the remaining relayers are not
+// started and the error is returned; relayers that were already started are left running.
+func StartRelayers(ctx context.Context, rep RelayerExecReporter, stagger time.Duration, relayers []Relayer, pathNames ...string) error {
+	for i, r := range relayers {
+		if i > 0 && stagger > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(stagger):
+			}
+		}
+		if err := r.StartRelayer(ctx, rep, pathNames...); err != nil {
+			return fmt.Errorf("failed to start relayer %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// TransferOptionsChecker is implemented by relayers that can tell whether they are able to relay
+// a transfer sent with the given options, e.g. one that can time out on a timestamp.
+type TransferOptionsChecker interface {
+	CheckTransferOptions(opts TransferOptions) error
+}
+
+// SendIBCTransfer sends a transfer from chain over channelID, to be relayed by r.
+// If r implements TransferOptionsChecker and cannot relay a transfer sent with options,
+// its error is returned without sending the transfer.
+func SendIBCTransfer(ctx context.Context, r Relayer, chain Chain, channelID, keyName string, amount WalletAmount, options TransferOptions) (Tx, error) {
+	if c, ok := r.(TransferOptionsChecker); ok {
+		if err := c.CheckTransferOptions(options); err != nil {
+			return Tx{}, err
+		}
+	}
+	return chain.SendIBCTransfer(ctx, channelID, keyName, amount, options)
+}
+
 // GetTransferChannel will return the transfer channel assuming only one client,
 // one connection, and one channel with "transfer" port exists between two chains.
 func GetTransferChannel(ctx context.Context, r Relayer, rep RelayerExecReporter, srcChainID, dstChainID string) (*ChannelOutput, error) {
@@ -270,6 +480,15 @@ func (o Order) Validate() error {
 // CreateClientOptions contains the configuration for creating a client.
 type CreateClientOptions struct {
 	TrustingPeriod string
+
+	// TrustedHeight, if non-zero, is the height of the reference chain whose consensus state the new client
+	// should initially trust, instead of the latest height. Not all relayers support this.
+	TrustedHeight uint64
+
+	// ClientType, if set, is the type of light client to create, e.g. "07-tendermint".
+	// Client IDs are assigned by the host chain as "<client type>-<sequence>", so the type determines their prefix.
+	// Not all relayers support every client type.
+	ClientType string
 }
 
 // DefaultClientOpts returns the default settings for creating clients.
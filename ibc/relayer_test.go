@@ -1,7 +1,10 @@
 package ibc
 
 import (
+	"context"
+	"errors"
 	"testing"
+	"time"
 
 	chantypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
 	"github.com/stretchr/testify/require"
@@ -34,3 +37,210 @@ func TestChannelOptsConfigured(t *testing.T) {
 	}
 	require.Error(t, opts.Validate())
 }
+
+// mockConnectionRelayer returns a successive ConnectionOutputs on each call to GetConnections.
+// After hangAfter calls, if set, GetConnections blocks until the context is done, like a query interrupted by a deadline.
+// Calling any other Relayer method panics.
+type mockConnectionRelayer struct {
+	Relayer
+
+	results   []ConnectionOutputs
+	calls     int
+	hangAfter int
+}
+
+func (r *mockConnectionRelayer) GetConnections(ctx context.Context, _ RelayerExecReporter, _ string) (ConnectionOutputs, error) {
+	if r.hangAfter > 0 && r.calls >= r.hangAfter {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	res := r.results[min(r.calls, len(r.results)-1)]
+	r.calls++
+	return res, nil
+}
+
+func TestGetConnection(t *testing.T) {
+	ctx := context.Background()
+	r := &mockConnectionRelayer{results: []ConnectionOutputs{{
+		{ID: "connection-0", State: "STATE_OPEN"},
+		{ID: "connection-1", State: "STATE_INIT"},
+	}}}
+
+	conn, err := GetConnection(ctx, r, NopRelayerExecReporter{}, "chain-a", "connection-1")
+	require.NoError(t, err)
+	require.Equal(t, "connection-1", conn.ID)
+	require.False(t, conn.IsOpen())
+
+	_, err = GetConnection(ctx, r, NopRelayerExecReporter{}, "chain-a", "connection-2")
+	require.ErrorIs(t, err, ErrConnectionNotFound)
+}
+
+func TestWaitForConnectionOpen(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("progresses to open", func(t *testing.T) {
+		r := &mockConnectionRelayer{results: []ConnectionOutputs{
+			{},
+			{{ID: "connection-0", State: "Init"}},
+			{{ID: "connection-0", State: "TryOpen"}},
+			{{ID: "connection-0", State: "Open"}},
+		}}
+		require.NoError(t, WaitForConnectionOpen(ctx, r, NopRelayerExecReporter{}, "chain-a", time.Minute, "connection-0"))
+		require.Equal(t, 4, r.calls)
+	})
+
+	t.Run("waits for every connection", func(t *testing.T) {
+		r := &mockConnectionRelayer{results: []ConnectionOutputs{
+			{{ID: "connection-0", State: "Open"}},
+			{{ID: "connection-0", State: "Open"}, {ID: "connection-1", State: "Open"}},
+		}}
+		require.NoError(t, WaitForConnectionOpen(ctx, r, NopRelayerExecReporter{}, "chain-a", time.Minute, "connection-0", "connection-1"))
+		require.Equal(t, 2, r.calls)
+	})
+
+	t.Run("times out", func(t *testing.T) {
+		r := &mockConnectionRelayer{results: []ConnectionOutputs{
+			{{ID: "connection-0", State: "STATE_OPEN"}, {ID: "connection-1", State: "STATE_INIT"}},
+		}}
+		err := WaitForConnectionOpen(ctx, r, NopRelayerExecReporter{}, "chain-a", 1500*time.Millisecond, "connection-0", "connection-1", "connection-2")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.ErrorContains(t, err, `connections on chain-a not open after 1.5s: `+
+			`connection-1 (last state "STATE_INIT"), connection-2 (last state "not found")`)
+		require.NotContains(t, err.Error(), "connection-0")
+	})
+
+	t.Run("times out while querying", func(t *testing.T) {
+		r := &mockConnectionRelayer{
+			results:   []ConnectionOutputs{{{ID: "connection-0", State: "STATE_TRYOPEN"}}},
+			hangAfter: 1,
+		}
+		err := WaitForConnectionOpen(ctx, r, NopRelayerExecReporter{}, "chain-a", 1500*time.Millisecond, "connection-0")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.ErrorContains(t, err, `connection-0 (last state "STATE_TRYOPEN")`)
+	})
+}
+
+// mockChannelRelayer returns a successive set of channels on each call to GetChannels.
+// After hangAfter calls, if set, GetChannels blocks until the context is done, like a query interrupted by a deadline.
+// Calling any other Relayer method panics.
+type mockChannelRelayer struct {
+	Relayer
+
+	results   [][]ChannelOutput
+	calls     int
+	hangAfter int
+}
+
+func (r *mockChannelRelayer) GetChannels(ctx context.Context, _ RelayerExecReporter, _ string) ([]ChannelOutput, error) {
+	if r.hangAfter > 0 && r.calls >= r.hangAfter {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	res := r.results[min(r.calls, len(r.results)-1)]
+	r.calls++
+	return res, nil
+}
+
+func TestWaitForChannelClose(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("progresses to closed", func(t *testing.T) {
+		r := &mockChannelRelayer{results: [][]ChannelOutput{
+			{{ChannelID: "channel-0", State: "Open"}},
+			{{ChannelID: "channel-0", State: "Closed"}},
+		}}
+		require.NoError(t, WaitForChannelClose(ctx, r, NopRelayerExecReporter{}, "chain-a", "channel-0", time.Minute))
+		require.Equal(t, 2, r.calls)
+	})
+
+	t.Run("times out", func(t *testing.T) {
+		r := &mockChannelRelayer{results: [][]ChannelOutput{
+			{{ChannelID: "channel-0", State: "STATE_OPEN"}},
+		}}
+		err := WaitForChannelClose(ctx, r, NopRelayerExecReporter{}, "chain-a", "channel-0", 1500*time.Millisecond)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.ErrorContains(t, err, "STATE_OPEN")
+	})
+
+	t.Run("times out while querying", func(t *testing.T) {
+		r := &mockChannelRelayer{
+			results:   [][]ChannelOutput{{{ChannelID: "channel-0", State: "STATE_OPEN"}}},
+			hangAfter: 1,
+		}
+		err := WaitForChannelClose(ctx, r, NopRelayerExecReporter{}, "chain-a", "channel-0", 1500*time.Millisecond)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.ErrorContains(t, err, `(last state "STATE_OPEN")`)
+	})
+
+	t.Run("missing channel", func(t *testing.T) {
+		r := &mockChannelRelayer{results: [][]ChannelOutput{{}}}
+		_, err := GetChannel(ctx, r, NopRelayerExecReporter{}, "chain-a", "channel-0")
+		require.ErrorIs(t, err, ErrChannelNotFound)
+	})
+}
+
+type mockStartRelayer struct {
+	Relayer
+
+	startedAt *[]time.Time
+	err       error
+}
+
+func (r mockStartRelayer) StartRelayer(context.Context, RelayerExecReporter, ...string) error {
+	*r.startedAt = append(*r.startedAt, time.Now())
+	return r.err
+}
+
+func TestStartRelayers(t *testing.T) {
+	ctx := context.Background()
+
+	var startedAt []time.Time
+	relayers := []Relayer{
+		mockStartRelayer{startedAt: &startedAt},
+		mockStartRelayer{startedAt: &startedAt},
+		mockStartRelayer{startedAt: &startedAt},
+	}
+
+	const stagger = 50 * time.Millisecond
+	require.NoError(t, StartRelayers(ctx, NopRelayerExecReporter{}, stagger, relayers, "p"))
+	require.Len(t, startedAt, 3)
+	for i := 1; i < len(startedAt); i++ {
+		require.GreaterOrEqual(t, startedAt[i].Sub(startedAt[i-1]), stagger)
+	}
+
+	startedAt = nil
+	relayers[1] = mockStartRelayer{startedAt: &startedAt, err: errors.New("boom")}
+	err := StartRelayers(ctx, NopRelayerExecReporter{}, 0, relayers, "p")
+	require.ErrorContains(t, err, "failed to start relayer 1: boom")
+	require.Len(t, startedAt, 2)
+}
+
+// mockClientRelayer returns the same ClientOutputs on each call to GetClients.
+// Calling any other Relayer method panics.
+type mockClientRelayer struct {
+	Relayer
+
+	clients ClientOutputs
+}
+
+func (r *mockClientRelayer) GetClients(context.Context, RelayerExecReporter, string) (ClientOutputs, error) {
+	return r.clients, nil
+}
+
+func TestAssertClientCounts(t *testing.T) {
+	ctx := context.Background()
+	r := &mockClientRelayer{clients: ClientOutputs{
+		{ClientID: "07-tendermint-0", ClientState: ClientState{ChainID: "chain-b"}},
+		{ClientID: "09-localhost"},
+	}}
+
+	require.NoError(t, AssertClientCounts(ctx, r, NopRelayerExecReporter{}, "chain-a", map[string]int{"07-tendermint": 1, "09-localhost": 1}))
+
+	// Linking the path a second time creates a duplicate client.
+	r.clients = append(r.clients, &ClientOutput{ClientID: "07-tendermint-1", ClientState: ClientState{ChainID: "chain-b"}})
+	err := AssertClientCounts(ctx, r, NopRelayerExecReporter{}, "chain-a", map[string]int{"07-tendermint": 1, "09-localhost": 1})
+	require.EqualError(t, err, "unexpected clients on chain-a: 2 07-tendermint clients [07-tendermint-0 07-tendermint-1], expected 1")
+
+	err = AssertClientCounts(ctx, r, NopRelayerExecReporter{}, "chain-a", map[string]int{"07-tendermint": 2, "08-wasm": 1})
+	require.EqualError(t, err, "unexpected clients on chain-a: 0 08-wasm clients [], expected 1; 1 09-localhost clients [09-localhost], expected 0")
+}
@@ -10,6 +10,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/module/testutil"
 	ibcexported "github.com/cosmos/ibc-go/v8/modules/core/03-connection/types"
+	chantypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
 )
 
 // ChainConfig defines the chain parameters requires to run an interchaintest testnet for a chain.
@@ -275,6 +276,23 @@ type ChannelOutput struct {
 	ChannelID      string              `json:"channel_id"`
 }
 
+// stateIs reports whether state is the given channel or connection state.
+// Relayers report the state in different formats, e.g. "STATE_OPEN" or "Open", so both the protobuf name
+// and the short name are accepted.
+func stateIs(state, protoName, shortName string) bool {
+	return state == protoName || state == shortName
+}
+
+// IsOpen reports whether the channel handshake has completed and the channel has not been closed.
+func (c ChannelOutput) IsOpen() bool {
+	return stateIs(c.State, chantypes.OPEN.String(), "Open")
+}
+
+// IsClosed reports whether the channel has been closed.
+func (c ChannelOutput) IsClosed() bool {
+	return stateIs(c.State, chantypes.CLOSED.String(), "Closed")
+}
+
 // ConnectionOutput represents the IBC connection information queried from a chain's state for a particular connection.
 type ConnectionOutput struct {
 	ID           string                    `json:"id,omitempty" yaml:"id"`
@@ -285,8 +303,31 @@ type ConnectionOutput struct {
 	DelayPeriod  string                    `json:"delay_period,omitempty" yaml:"delay_period"`
 }
 
+// IsOpen reports whether the connection has completed its handshake.
+func (c ConnectionOutput) IsOpen() bool {
+	return stateIs(c.State, ibcexported.OPEN.String(), "Open")
+}
+
 type ConnectionOutputs []*ConnectionOutput
 
+// ConnectionHandshake holds the identifiers on both ends of a connection after a completed handshake.
+type ConnectionHandshake struct {
+	SrcConnID   string
+	DstConnID   string
+	SrcClientID string
+	DstClientID string
+}
+
+// Find returns the connection with the given connection ID and a boolean indicating if it was found.
+func (c ConnectionOutputs) Find(connectionID string) (*ConnectionOutput, bool) {
+	for _, conn := range c {
+		if conn.ID == connectionID {
+			return conn, true
+		}
+	}
+	return nil, false
+}
+
 type ClientOutput struct {
 	ClientID    string      `json:"client_id"`
 	ClientState ClientState `json:"client_state"`
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"cosmossdk.io/math"
+	"github.com/cosmos/cosmos-sdk/types"
 	"github.com/docker/docker/client"
 	"github.com/strangelove-ventures/interchaintest/v8/ibc"
 	"github.com/strangelove-ventures/interchaintest/v8/testreporter"
@@ -209,6 +210,19 @@ type InterchainBuildOptions struct {
 
 	// If set, saves block history to a sqlite3 database to aid debugging.
 	BlockDatabaseFile string
+
+	// Optional. If set, every relayer key is funded on its chain as soon as it is restored to the relayer,
+	// in addition to the funds it receives at genesis.
+	FundRelayerKeys *RelayerKeyFunding
+}
+
+// RelayerKeyFunding describes how relayer keys are funded during (*Interchain).Build.
+type RelayerKeyFunding struct {
+	// Key name on each chain that funds the relayer keys. Defaults to the faucet key.
+	Source string
+
+	// Amount of the native denom of each chain sent to every relayer key.
+	Amount math.Int
 }
 
 // Build starts all the chains and configures the relayers associated with the Interchain.
@@ -260,7 +274,7 @@ func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecRe
 		}
 	}
 
-	if err := ic.configureRelayerKeys(ctx, rep); err != nil {
+	if err := ic.configureRelayerKeys(ctx, rep, opts.FundRelayerKeys); err != nil {
 		// Error already wrapped with appropriate detail.
 		return err
 	}
@@ -408,7 +422,8 @@ func (ic *Interchain) generateRelayerWallets(ctx context.Context) error {
 
 // configureRelayerKeys adds the chain configuration for each relayer
 // and adds the preconfigured key to the relayer for each relayer-chain.
-func (ic *Interchain) configureRelayerKeys(ctx context.Context, rep *testreporter.RelayerExecReporter) error {
+// If funding is set, each key is funded after it is added.
+func (ic *Interchain) configureRelayerKeys(ctx context.Context, rep *testreporter.RelayerExecReporter, funding *RelayerKeyFunding) error {
 	// Possible optimization: each relayer could be configured concurrently.
 	// But we are only testing with a single relayer so far, so we don't need this yet.
 
@@ -435,12 +450,53 @@ func (ic *Interchain) configureRelayerKeys(ctx context.Context, rep *testreporte
 			); err != nil {
 				return fmt.Errorf("failed to restore key to relayer %s for chain %s: %w", ic.relayers[r], chainName, err)
 			}
+
+			if funding != nil {
+				wallet := ic.relayerWallets[relayerChain{R: r, C: c}]
+				if err := fundRelayerKey(ctx, c, wallet, *funding); err != nil {
+					return fmt.Errorf("failed to fund key of relayer %s for chain %s: %w", ic.relayers[r], chainName, err)
+				}
+			}
 		}
 	}
 
 	return nil
 }
 
+// fundRelayerKey sends the funding amount to the wallet, after checking that the funding source can afford it.
+func fundRelayerKey(ctx context.Context, c ibc.Chain, wallet ibc.Wallet, funding RelayerKeyFunding) error {
+	if funding.Amount.IsNil() || !funding.Amount.IsPositive() {
+		return fmt.Errorf("funding amount must be positive")
+	}
+	source := funding.Source
+	if source == "" {
+		source = FaucetAccountKeyName
+	}
+	denom := c.Config().Denom
+
+	sourceAddrBytes, err := c.GetAddress(ctx, source)
+	if err != nil {
+		return fmt.Errorf("failed to get address of funding source %s: %w", source, err)
+	}
+	sourceAddr, err := types.Bech32ifyAddressBytes(c.Config().Bech32Prefix, sourceAddrBytes)
+	if err != nil {
+		return err
+	}
+	balance, err := c.GetBalance(ctx, sourceAddr, denom)
+	if err != nil {
+		return fmt.Errorf("failed to get balance of funding source %s: %w", source, err)
+	}
+	if balance.LT(funding.Amount) {
+		return fmt.Errorf("funding source %s has insufficient funds: balance %s%s is less than %s%s", source, balance, denom, funding.Amount, denom)
+	}
+
+	return c.SendFunds(ctx, source, ibc.WalletAmount{
+		Address: wallet.FormattedAddress(),
+		Denom:   denom,
+		Amount:  funding.Amount,
+	})
+}
+
 // relayerChain is a tuple of a Relayer and a Chain.
 type relayerChain struct {
 	R ibc.Relayer
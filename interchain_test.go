@@ -169,6 +169,66 @@ func getRelayerWalletsTest(t *testing.T, relayerImpl ibc.RelayerImplementation)
 	_ = ic.Close()
 }
 
+func TestInterchain_FundRelayerKeys(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	client, network := interchaintest.DockerSetup(t)
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", ChainName: "g1", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-0"}},
+		{Name: "gaia", ChainName: "g2", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-1"}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	gaia0, gaia1 := chains[0], chains[1]
+
+	r := interchaintest.NewBuiltinRelayerFactory(ibc.Hermes, zaptest.NewLogger(t)).Build(
+		t, client, network,
+	)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(gaia0).
+		AddChain(gaia1).
+		AddRelayer(r, "r").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  gaia0,
+			Chain2:  gaia1,
+			Relayer: r,
+		})
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	ctx := context.Background()
+	funding := math.NewInt(5_000_000)
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+
+		SkipPathCreation: true,
+		FundRelayerKeys:  &interchaintest.RelayerKeyFunding{Amount: funding},
+	}))
+	defer ic.Close()
+
+	// Relayer wallets receive 1t units of denom at genesis.
+	genesisAmount := math.NewInt(1_000_000_000_000)
+	for _, c := range chains {
+		wallet, ok := r.GetWallet(c.Config().ChainID)
+		require.True(t, ok)
+
+		balance, err := c.GetBalance(ctx, wallet.FormattedAddress(), c.Config().Denom)
+		require.NoError(t, err)
+		require.Equal(t, genesisAmount.Add(funding), balance)
+	}
+}
+
 func TestInterchain_CreateUser(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping in short mode")
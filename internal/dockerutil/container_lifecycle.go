@@ -2,9 +2,11 @@ package dockerutil
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"strings"
+	"time"
 
 	dockertypes "github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
@@ -25,6 +27,55 @@ type ContainerLifecycle struct {
 	preStartListeners Listeners
 }
 
+// ContainerOpt applies additional configuration to a container created through CreateContainer.
+type ContainerOpt func(cfg *container.Config, hostCfg *container.HostConfig)
+
+// WithLabels attaches the given labels to the container.
+// Labels that are already set, such as the CleanupLabel, are not overridden.
+func WithLabels(labels map[string]string) ContainerOpt {
+	return func(cfg *container.Config, _ *container.HostConfig) {
+		cfg.Labels = MergeLabels(cfg.Labels, labels)
+	}
+}
+
+// WithExtraHosts adds "host:ip" mappings to the container's /etc/hosts, like "docker run --add-host".
+func WithExtraHosts(hosts []string) ContainerOpt {
+	return func(_ *container.Config, hostCfg *container.HostConfig) {
+		hostCfg.ExtraHosts = append(hostCfg.ExtraHosts, hosts...)
+	}
+}
+
+// WithDNS sets custom DNS servers for the container, like "docker run --dns".
+func WithDNS(servers []string) ContainerOpt {
+	return func(_ *container.Config, hostCfg *container.HostConfig) {
+		hostCfg.DNS = append(hostCfg.DNS, servers...)
+	}
+}
+
+// WithResources sets the resource limits of the container, e.g. memory and CPU.
+func WithResources(resources container.Resources) ContainerOpt {
+	return func(_ *container.Config, hostCfg *container.HostConfig) {
+		hostCfg.Resources = resources
+	}
+}
+
+// WithRestartPolicy sets the restart policy of the container, like "docker run --restart".
+func WithRestartPolicy(policy container.RestartPolicy) ContainerOpt {
+	return func(_ *container.Config, hostCfg *container.HostConfig) {
+		hostCfg.RestartPolicy = policy
+	}
+}
+
+// WithLogConfig sets the logging driver of the container and its options, like "docker run --log-driver --log-opt".
+// An empty driver keeps the daemon's default.
+func WithLogConfig(logConfig container.LogConfig) ContainerOpt {
+	return func(_ *container.Config, hostCfg *container.HostConfig) {
+		if logConfig.Type != "" {
+			hostCfg.LogConfig = logConfig
+		}
+	}
+}
+
 func NewContainerLifecycle(log *zap.Logger, client *dockerclient.Client, containerName string) *ContainerLifecycle {
 	return &ContainerLifecycle{
 		log:           log,
@@ -43,6 +94,7 @@ func (c *ContainerLifecycle) CreateContainer(
 	hostName string,
 	cmd []string,
 	env []string,
+	opts ...ContainerOpt,
 ) error {
 	imageRef := image.Ref()
 	c.log.Info(
@@ -59,28 +111,34 @@ func (c *ContainerLifecycle) CreateContainer(
 
 	c.preStartListeners = listeners
 
-	cc, err := c.client.ContainerCreate(
-		ctx,
-		&container.Config{
-			Image: imageRef,
+	cfg := &container.Config{
+		Image: imageRef,
 
-			Entrypoint: []string{},
-			Env:        env,
-			Cmd:        cmd,
+		Entrypoint: []string{},
+		Env:        env,
+		Cmd:        cmd,
 
-			Hostname: hostName,
+		Hostname: hostName,
 
-			Labels: map[string]string{CleanupLabel: testName},
+		Labels: map[string]string{CleanupLabel: testName},
 
-			ExposedPorts: ports,
-		},
-		&container.HostConfig{
-			Binds:           volumeBinds,
-			PortBindings:    pb,
-			PublishAllPorts: true,
-			AutoRemove:      false,
-			DNS:             []string{},
-		},
+		ExposedPorts: ports,
+	}
+	hostCfg := &container.HostConfig{
+		Binds:           volumeBinds,
+		PortBindings:    pb,
+		PublishAllPorts: true,
+		AutoRemove:      false,
+		DNS:             []string{},
+	}
+	for _, opt := range opts {
+		opt(cfg, hostCfg)
+	}
+
+	cc, err := c.client.ContainerCreate(
+		ctx,
+		cfg,
+		hostCfg,
 		&network.NetworkingConfig{
 			EndpointsConfig: map[string]*network.EndpointSettings{
 				networkID: {},
@@ -171,3 +229,63 @@ func (c *ContainerLifecycle) Running(ctx context.Context) error {
 	}
 	return fmt.Errorf("container with name %s and id %s is not running", c.containerName, c.id)
 }
+
+// Paused will inspect the container and check its state to determine if it is currently paused.
+// If the container is paused nil will be returned, otherwise an error is returned.
+func (c *ContainerLifecycle) Paused(ctx context.Context) error {
+	cjson, err := c.client.ContainerInspect(ctx, c.id)
+	if err != nil {
+		return err
+	}
+	if cjson.State.Paused {
+		return nil
+	}
+	return fmt.Errorf("container with name %s and id %s is not paused", c.containerName, c.id)
+}
+
+// IsRunning reports whether the container exists and its process is running and not paused.
+// Unlike Running, a missing container is not an error.
+func (c *ContainerLifecycle) IsRunning(ctx context.Context) (bool, error) {
+	cjson, err := c.client.ContainerInspect(ctx, c.id)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return cjson.State.Running && !cjson.State.Paused, nil
+}
+
+// ErrCrashLoop is returned by WatchStartup when the container does not stay up after being started.
+var ErrCrashLoop = errors.New("container exited after start")
+
+// WatchStartup polls the container until it has been running for the given window.
+// It returns an error wrapping ErrCrashLoop as soon as the container exits and is not being restarted,
+// or has been restarted more than maxRestarts times by its restart policy.
+func (c *ContainerLifecycle) WatchStartup(ctx context.Context, window time.Duration, maxRestarts int) error {
+	const pollInterval = 250 * time.Millisecond
+
+	deadline := time.Now().Add(window)
+	for {
+		cjson, err := c.client.ContainerInspect(ctx, c.id)
+		if err != nil {
+			return err
+		}
+		state := cjson.State
+		switch {
+		case cjson.RestartCount > maxRestarts:
+			return fmt.Errorf("%w: container %s restarted %d times, last exit code %d", ErrCrashLoop, c.containerName, cjson.RestartCount, state.ExitCode)
+		case !state.Running && !state.Restarting:
+			return fmt.Errorf("%w: container %s exited with code %d", ErrCrashLoop, c.containerName, state.ExitCode)
+		}
+
+		if time.Now().After(deadline) {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
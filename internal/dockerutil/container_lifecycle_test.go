@@ -0,0 +1,55 @@
+package dockerutil_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v8"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/internal/dockerutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestContainerLifecycle_IsRunning(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+
+	t.Parallel()
+
+	cli, network := interchaintest.DockerSetup(t)
+	ctx := context.Background()
+
+	image := ibc.DockerImage{Repository: "busybox", Version: "stable"}
+	rc, err := cli.ImagePull(ctx, image.Ref(), types.ImagePullOptions{})
+	require.NoError(t, err)
+	_, _ = io.Copy(io.Discard, rc)
+	_ = rc.Close()
+
+	name := dockerutil.SanitizeContainerName(t.Name() + "-" + dockerutil.RandLowerCaseLetterString(5))
+	c := dockerutil.NewContainerLifecycle(zaptest.NewLogger(t), cli, name)
+
+	running, err := c.IsRunning(ctx)
+	require.NoError(t, err)
+	require.False(t, running, "container does not exist yet")
+
+	require.NoError(t, c.CreateContainer(ctx, t.Name(), network, image, nil, nil, "", []string{"sleep", "600"}, nil))
+	require.NoError(t, c.StartContainer(ctx))
+
+	running, err = c.IsRunning(ctx)
+	require.NoError(t, err)
+	require.True(t, running)
+
+	require.NoError(t, c.StopContainer(ctx))
+	running, err = c.IsRunning(ctx)
+	require.NoError(t, err)
+	require.False(t, running)
+
+	require.NoError(t, c.RemoveContainer(ctx))
+	running, err = c.IsRunning(ctx)
+	require.NoError(t, err)
+	require.False(t, running)
+}
@@ -0,0 +1,84 @@
+package dockerutil
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/docker/docker/client"
+)
+
+// dockerContextMeta is the subset of a docker context's meta.json needed to resolve its endpoint.
+type dockerContextMeta struct {
+	Endpoints map[string]struct {
+		Host string `json:"Host"`
+	} `json:"Endpoints"`
+}
+
+// dockerConfigFile is the subset of the docker CLI config.json needed to resolve the current context.
+type dockerConfigFile struct {
+	CurrentContext string `json:"currentContext"`
+}
+
+// ResolveDockerHost returns the docker daemon endpoint to connect to.
+//
+// DOCKER_HOST takes precedence, followed by the context named in DOCKER_CONTEXT,
+// followed by the current context selected with "docker context use".
+// If none of those are set, or the default context is selected, the client's default host is returned.
+func ResolveDockerHost() (string, error) {
+	if host := os.Getenv(client.EnvOverrideHost); host != "" {
+		return host, nil
+	}
+
+	configDir := os.Getenv("DOCKER_CONFIG")
+	if configDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return client.DefaultDockerHost, nil
+		}
+		configDir = filepath.Join(home, ".docker")
+	}
+
+	contextName := os.Getenv("DOCKER_CONTEXT")
+	if contextName == "" {
+		bz, err := os.ReadFile(filepath.Join(configDir, "config.json"))
+		if err == nil {
+			var cfg dockerConfigFile
+			if err := json.Unmarshal(bz, &cfg); err != nil {
+				return "", fmt.Errorf("parsing docker config: %w", err)
+			}
+			contextName = cfg.CurrentContext
+		}
+	}
+
+	if contextName == "" || contextName == "default" {
+		return client.DefaultDockerHost, nil
+	}
+
+	return dockerContextHost(configDir, contextName)
+}
+
+// dockerContextHost reads the docker endpoint of the named context from the docker CLI config directory.
+func dockerContextHost(configDir, contextName string) (string, error) {
+	digest := sha256.Sum256([]byte(contextName))
+	metaPath := filepath.Join(configDir, "contexts", "meta", hex.EncodeToString(digest[:]), "meta.json")
+
+	bz, err := os.ReadFile(metaPath)
+	if err != nil {
+		return "", fmt.Errorf("reading docker context %q: %w", contextName, err)
+	}
+
+	var meta dockerContextMeta
+	if err := json.Unmarshal(bz, &meta); err != nil {
+		return "", fmt.Errorf("parsing docker context %q: %w", contextName, err)
+	}
+
+	endpoint, ok := meta.Endpoints["docker"]
+	if !ok || endpoint.Host == "" {
+		return "", fmt.Errorf("docker context %q has no docker endpoint", contextName)
+	}
+	return endpoint.Host, nil
+}
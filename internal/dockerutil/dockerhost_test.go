@@ -0,0 +1,80 @@
+package dockerutil_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/docker/client"
+	"github.com/strangelove-ventures/interchaintest/v8/internal/dockerutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveDockerHost(t *testing.T) {
+	configDir := t.TempDir()
+	writeDockerContext(t, configDir, "colima", "unix:///Users/me/.colima/default/docker.sock")
+
+	t.Run("DOCKER_HOST", func(t *testing.T) {
+		t.Setenv("DOCKER_CONFIG", configDir)
+		t.Setenv("DOCKER_CONTEXT", "colima")
+		t.Setenv("DOCKER_HOST", "tcp://10.0.0.5:2375")
+
+		host, err := dockerutil.ResolveDockerHost()
+		require.NoError(t, err)
+		require.Equal(t, "tcp://10.0.0.5:2375", host)
+	})
+
+	t.Run("DOCKER_CONTEXT", func(t *testing.T) {
+		t.Setenv("DOCKER_CONFIG", configDir)
+		t.Setenv("DOCKER_CONTEXT", "colima")
+		t.Setenv("DOCKER_HOST", "")
+
+		host, err := dockerutil.ResolveDockerHost()
+		require.NoError(t, err)
+		require.Equal(t, "unix:///Users/me/.colima/default/docker.sock", host)
+	})
+
+	t.Run("current context", func(t *testing.T) {
+		t.Setenv("DOCKER_CONFIG", configDir)
+		t.Setenv("DOCKER_CONTEXT", "")
+		t.Setenv("DOCKER_HOST", "")
+		require.NoError(t, os.WriteFile(filepath.Join(configDir, "config.json"), []byte(`{"currentContext":"colima"}`), 0600))
+		defer os.Remove(filepath.Join(configDir, "config.json"))
+
+		host, err := dockerutil.ResolveDockerHost()
+		require.NoError(t, err)
+		require.Equal(t, "unix:///Users/me/.colima/default/docker.sock", host)
+	})
+
+	t.Run("default", func(t *testing.T) {
+		t.Setenv("DOCKER_CONFIG", configDir)
+		t.Setenv("DOCKER_CONTEXT", "")
+		t.Setenv("DOCKER_HOST", "")
+
+		host, err := dockerutil.ResolveDockerHost()
+		require.NoError(t, err)
+		require.Equal(t, client.DefaultDockerHost, host)
+	})
+
+	t.Run("unknown context", func(t *testing.T) {
+		t.Setenv("DOCKER_CONFIG", configDir)
+		t.Setenv("DOCKER_CONTEXT", "missing")
+		t.Setenv("DOCKER_HOST", "")
+
+		_, err := dockerutil.ResolveDockerHost()
+		require.ErrorContains(t, err, `docker context "missing"`)
+	})
+}
+
+func writeDockerContext(t *testing.T, configDir, name, host string) {
+	t.Helper()
+
+	digest := sha256.Sum256([]byte(name))
+	dir := filepath.Join(configDir, "contexts", "meta", hex.EncodeToString(digest[:]))
+	require.NoError(t, os.MkdirAll(dir, 0755))
+
+	meta := `{"Name":"` + name + `","Metadata":{},"Endpoints":{"docker":{"Host":"` + host + `","SkipTLSVerify":false}}}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "meta.json"), []byte(meta), 0600))
+}
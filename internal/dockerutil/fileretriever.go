@@ -14,8 +14,7 @@ import (
 	"go.uber.org/zap"
 )
 
-// FileRetriever allows retrieving a single file from a Docker volume.
-// In the future it may allow retrieving an entire directory.
+// FileRetriever allows retrieving a single file, or an archive of an entire directory, from a Docker volume.
 type FileRetriever struct {
 	log *zap.Logger
 
@@ -32,10 +31,56 @@ func NewFileRetriever(log *zap.Logger, cli *client.Client, testName string) *Fil
 // SingleFileContent returns the content of the file named at relPath,
 // inside the volume specified by volumeName.
 func (r *FileRetriever) SingleFileContent(ctx context.Context, volumeName, relPath string) ([]byte, error) {
+	var content []byte
+	err := r.copyFromVolume(ctx, volumeName, relPath, func(rc io.Reader) error {
+		wantPath := path.Base(relPath)
+		tr := tar.NewReader(rc)
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return fmt.Errorf("reading tar from container: %w", err)
+			}
+			if hdr.Name != wantPath {
+				r.log.Debug("Unexpected path", zap.String("want", relPath), zap.String("got", hdr.Name))
+				continue
+			}
+
+			content, err = io.ReadAll(tr)
+			return err
+		}
+
+		return fmt.Errorf("path %q not found in tar from container", relPath)
+	})
+	return content, err
+}
+
+// Archive returns a tar archive of the file or directory named at relPath,
+// inside the volume specified by volumeName.
+// Entries in the archive are prefixed with the base name of relPath, or "dockervolume" when relPath is empty.
+func (r *FileRetriever) Archive(ctx context.Context, volumeName, relPath string) ([]byte, error) {
+	var archive []byte
+	err := r.copyFromVolume(ctx, volumeName, relPath, func(rc io.Reader) error {
+		var err error
+		archive, err = io.ReadAll(rc)
+		if err != nil {
+			return fmt.Errorf("reading tar from container: %w", err)
+		}
+		return nil
+	})
+	return archive, err
+}
+
+// copyFromVolume mounts the volume in a temporary container that is never started,
+// and passes the tar stream of relPath to fn.
+// Because the container is not started, this works regardless of whether another container is using the volume.
+func (r *FileRetriever) copyFromVolume(ctx context.Context, volumeName, relPath string, fn func(io.Reader) error) error {
 	const mountPath = "/mnt/dockervolume"
 
 	if err := ensureBusybox(ctx, r.cli); err != nil {
-		return nil, err
+		return err
 	}
 
 	containerName := fmt.Sprintf("interchaintest-getfile-%d-%s", time.Now().UnixNano(), RandLowerCaseLetterString(5))
@@ -59,7 +104,7 @@ func (r *FileRetriever) SingleFileContent(ctx context.Context, volumeName, relPa
 		containerName,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("creating container: %w", err)
+		return fmt.Errorf("creating container: %w", err)
 	}
 
 	defer func() {
@@ -72,29 +117,11 @@ func (r *FileRetriever) SingleFileContent(ctx context.Context, volumeName, relPa
 
 	rc, _, err := r.cli.CopyFromContainer(ctx, cc.ID, path.Join(mountPath, relPath))
 	if err != nil {
-		return nil, fmt.Errorf("copying from container: %w", err)
+		return fmt.Errorf("copying from container: %w", err)
 	}
 	defer func() {
 		_ = rc.Close()
 	}()
 
-	wantPath := path.Base(relPath)
-	tr := tar.NewReader(rc)
-	for {
-		hdr, err := tr.Next()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			return nil, fmt.Errorf("reading tar from container: %w", err)
-		}
-		if hdr.Name != wantPath {
-			r.log.Debug("Unexpected path", zap.String("want", relPath), zap.String("got", hdr.Name))
-			continue
-		}
-
-		return io.ReadAll(tr)
-	}
-
-	return nil, fmt.Errorf("path %q not found in tar from container", relPath)
+	return fn(rc)
 }
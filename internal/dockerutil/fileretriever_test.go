@@ -1,7 +1,10 @@
 package dockerutil_test
 
 import (
+	"archive/tar"
+	"bytes"
 	"context"
+	"io"
 	"testing"
 
 	volumetypes "github.com/docker/docker/api/types/volume"
@@ -66,4 +69,21 @@ func TestFileRetriever(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, string(b), "test")
 	})
+
+	t.Run("directory archive", func(t *testing.T) {
+		b, err := fr.Archive(ctx, v.Name, "foo")
+		require.NoError(t, err)
+
+		var names []string
+		tr := tar.NewReader(bytes.NewReader(b))
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			names = append(names, hdr.Name)
+		}
+		require.Contains(t, names, "foo/bar/baz.txt")
+	})
 }
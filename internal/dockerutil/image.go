@@ -89,6 +89,22 @@ type ContainerOptions struct {
 
 	// If non-zero, will limit the amount of log lines returned.
 	LogTail uint64
+
+	// Additional labels to attach to the container, alongside the CleanupLabel.
+	Labels map[string]string
+
+	// Additional "host:ip" entries for the container's /etc/hosts.
+	ExtraHosts []string
+
+	// Custom DNS servers. If empty, docker's defaults are used.
+	DNS []string
+
+	// Resource limits, e.g. memory and CPU. Unlimited by default.
+	Resources container.Resources
+
+	// If set, the container's stdout and stderr are copied to Stream as they are produced.
+	// The output is still captured in the ContainerExecResult.
+	Stream io.Writer
 }
 
 // ContainerExecResult is a wrapper type that wraps an exit code and associated output from stderr & stdout, along with
@@ -113,7 +129,38 @@ func (image *Image) Run(ctx context.Context, cmd []string, opts ContainerOptions
 			Stderr:   nil,
 		}
 	}
-	return c.Wait(ctx, opts.LogTail)
+	if opts.Stream == nil {
+		return c.Wait(ctx, opts.LogTail)
+	}
+
+	streamed := make(chan struct{})
+	go func() {
+		defer close(streamed)
+		if err := StreamContainerLogs(ctx, image.client, c.containerID, opts.Stream); err != nil {
+			c.log.Info("Failed to stream container logs", zap.Error(err))
+		}
+	}()
+	res := c.Wait(ctx, opts.LogTail)
+	<-streamed
+	return res
+}
+
+// StreamContainerLogs copies the stdout and stderr of the container to w as they are produced,
+// until the container stops or ctx is done.
+func StreamContainerLogs(ctx context.Context, cli *client.Client, containerID string, w io.Writer) error {
+	rc, err := cli.ContainerLogs(ctx, containerID, types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     true,
+	})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rc.Close() }()
+
+	// Logs are multiplexed into one stream; see docs for ContainerLogs.
+	_, err = stdcopy.StdCopy(w, w, rc)
+	return err
 }
 
 func (image *Image) imageRef() string {
@@ -169,12 +216,15 @@ func (image *Image) createContainer(ctx context.Context, containerName, hostName
 			Hostname: hostName,
 			User:     opts.User,
 
-			Labels: map[string]string{CleanupLabel: image.testName},
+			Labels: MergeLabels(map[string]string{CleanupLabel: image.testName}, opts.Labels),
 		},
 		&container.HostConfig{
 			Binds:           opts.Binds,
 			PublishAllPorts: true, // Because we publish all ports, no need to expose specific ports.
 			AutoRemove:      false,
+			ExtraHosts:      opts.ExtraHosts,
+			DNS:             opts.DNS,
+			Resources:       opts.Resources,
 		},
 		&network.NetworkingConfig{
 			EndpointsConfig: map[string]*network.EndpointSettings{
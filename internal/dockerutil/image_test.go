@@ -1,6 +1,7 @@
 package dockerutil
 
 import (
+	"bytes"
 	"context"
 	"os"
 	"path/filepath"
@@ -64,6 +65,17 @@ func TestImage_Run(t *testing.T) {
 		require.Empty(t, string(stderr))
 	})
 
+	t.Run("stream", func(t *testing.T) {
+		var streamed bytes.Buffer
+		res := image.Run(ctx, []string{"sh", "-c", "echo -n out; echo -n err >&2"}, ContainerOptions{Stream: &streamed})
+
+		require.NoError(t, res.Err)
+		require.Equal(t, "out", string(res.Stdout))
+		require.Equal(t, "err", string(res.Stderr))
+		require.Contains(t, streamed.String(), "out")
+		require.Contains(t, streamed.String(), "err")
+	})
+
 	t.Run("binds", func(t *testing.T) {
 		const scriptBody = `#!/bin/sh
 echo -n hi from stderr >> /dev/stderr
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/avast/retry-go/v4"
@@ -45,6 +46,9 @@ const (
 
 	// NodeOwnerLabel indicates the logical node owning a particular object (probably a volume).
 	NodeOwnerLabel = LabelPrefix + "node-owner"
+
+	// RelayerImageLabel indicates the relayer image, including version, owning a particular object.
+	RelayerImageLabel = LabelPrefix + "relayer-image"
 )
 
 // KeepVolumesOnFailure determines whether volumes associated with a test
@@ -57,29 +61,123 @@ const (
 // is interchaintest.KeepDockerVolumesOnFailure(bool).
 var KeepVolumesOnFailure = os.Getenv("IBCTEST_SKIP_FAILURE_CLEANUP") != ""
 
+// KeepResourcesOnFailure determines whether all docker resources associated with a test
+// using DockerSetup, i.e. containers, volumes and networks, are retained following a test failure
+// so that they can be inspected. Resources are still cleaned up when the test passes.
+//
+// The value is false by default, but can be initialized to true by setting the
+// environment variable IBCTEST_SKIP_CLEANUP to a non-empty value.
+// The public API for setting this value is interchaintest.KeepDockerResourcesOnFailure(bool).
+var KeepResourcesOnFailure = os.Getenv("IBCTEST_SKIP_CLEANUP") != ""
+
+// KeepNetworks determines whether the networks associated with a test using DockerSetup
+// are retained rather than removed during cleanup, e.g. when they are shared with resources
+// managed outside of interchaintest. Containers and volumes are still cleaned up.
+//
+// The value is false by default, but can be initialized to true by setting the
+// environment variable IBCTEST_SKIP_NETWORK_CLEANUP to a non-empty value.
+// The public API for setting this value is interchaintest.KeepDockerNetworks(bool).
+var KeepNetworks = os.Getenv("IBCTEST_SKIP_NETWORK_CLEANUP") != ""
+
+// NetworkCleanupGracePeriod is how long cleanup keeps retrying to remove a network of a test that still has
+// active endpoints, e.g. because a container stopped during cleanup has not fully detached from it yet.
+// The public API for setting this value is interchaintest.DockerNetworkCleanupGracePeriod(time.Duration).
+var NetworkCleanupGracePeriod = 10 * time.Second
+
+// networkRemoveRetryDelay is how often cleanup retries to remove a network with active endpoints.
+const networkRemoveRetryDelay = 500 * time.Millisecond
+
+var (
+	cleanupHooksMu sync.Mutex
+	cleanupHooks   = map[string][]func(context.Context) error{}
+)
+
+// RegisterCleanupHook registers fn to run when the docker resources of the named test are cleaned up,
+// before its containers and volumes are removed, e.g. to copy files out of a volume for post-mortem debugging.
+// Hooks run once, in the order they were registered, and their errors are logged.
+func RegisterCleanupHook(testName string, fn func(ctx context.Context) error) {
+	cleanupHooksMu.Lock()
+	defer cleanupHooksMu.Unlock()
+	cleanupHooks[testName] = append(cleanupHooks[testName], fn)
+}
+
+// runCleanupHooks runs and unregisters the cleanup hooks of the named test.
+func runCleanupHooks(ctx context.Context, t DockerSetupTestingT) {
+	cleanupHooksMu.Lock()
+	hooks := cleanupHooks[t.Name()]
+	delete(cleanupHooks, t.Name())
+	cleanupHooksMu.Unlock()
+
+	for _, fn := range hooks {
+		if err := fn(ctx); err != nil {
+			t.Logf("Cleanup hook failed during docker cleanup: %v", err)
+		}
+	}
+}
+
+// DockerSetupOptions optionally configures DockerSetupWithOptions.
+type DockerSetupOptions struct {
+	// Labels are attached to the created network in addition to the CleanupLabel,
+	// e.g. to tag resources with a CI job ID for external tooling.
+	Labels map[string]string
+
+	// APIVersion pins the docker API version used by the client, e.g. "1.41" for an older daemon.
+	// If empty, the version set in the DOCKER_API_VERSION environment variable is used,
+	// and otherwise the version is negotiated with the daemon.
+	APIVersion string
+
+	// SkipEagerCleanup disables the cleanup of leftover resources labeled with the test name
+	// when the setup starts, e.g. because a concurrent run shares the test name.
+	// Resources are still cleaned up at the end of the test.
+	SkipEagerCleanup bool
+}
+
 // DockerSetup returns a new Docker Client and the ID of a configured network, associated with t.
 //
 // If any part of the setup fails, DockerSetup panics because the test cannot continue.
 func DockerSetup(t DockerSetupTestingT) (*client.Client, string) {
 	t.Helper()
+	return DockerSetupWithOptions(t, DockerSetupOptions{})
+}
+
+// DockerSetupWithOptions is like DockerSetup, but allows additional configuration of the created resources.
+func DockerSetupWithOptions(t DockerSetupTestingT, opts DockerSetupOptions) (*client.Client, string) {
+	t.Helper()
 
-	cli, err := client.NewClientWithOpts(client.FromEnv)
+	host, err := ResolveDockerHost()
 	if err != nil {
-		panic(fmt.Errorf("failed to create docker client: %v", err))
+		panic(fmt.Errorf("failed to resolve docker host: %v", err))
 	}
 
+	// Negotiation is skipped when a version is pinned, either through the environment or the options.
+	clientOpts := []client.Opt{client.FromEnv, client.WithHost(host), client.WithAPIVersionNegotiation()}
+	if opts.APIVersion != "" {
+		clientOpts = append(clientOpts, client.WithVersion(opts.APIVersion))
+	}
+	cli, err := client.NewClientWithOpts(clientOpts...)
+	if err != nil {
+		panic(fmt.Errorf("failed to create docker client for %s: %v", host, err))
+	}
+
+	if _, err := cli.Ping(context.TODO()); err != nil {
+		panic(fmt.Errorf("docker daemon not reachable at %s (set DOCKER_HOST or DOCKER_CONTEXT to use a different endpoint): %v", host, err))
+	}
+	t.Logf("Connected to docker daemon at %s", host)
+
 	// Clean up docker resources at end of test.
 	t.Cleanup(dockerCleanup(t, cli))
 
 	// Also eagerly clean up any leftover resources from a previous test run,
 	// e.g. if the test was interrupted.
-	dockerCleanup(t, cli)()
+	if !opts.SkipEagerCleanup {
+		dockerCleanup(t, cli)()
+	}
 
 	name := fmt.Sprintf("interchaintest-%s", RandLowerCaseLetterString(8))
 	network, err := cli.NetworkCreate(context.TODO(), name, types.NetworkCreate{
 		CheckDuplicate: true,
 
-		Labels: map[string]string{CleanupLabel: t.Name()},
+		Labels: MergeLabels(map[string]string{CleanupLabel: t.Name()}, opts.Labels),
 	})
 	if err != nil {
 		panic(fmt.Errorf("failed to create docker network: %v", err))
@@ -93,10 +191,12 @@ func dockerCleanup(t DockerSetupTestingT, cli *client.Client) func() {
 	return func() {
 		showContainerLogs := os.Getenv("SHOW_CONTAINER_LOGS")
 		containerLogTail := os.Getenv("CONTAINER_LOG_TAIL")
-		keepContainers := os.Getenv("KEEP_CONTAINTERS") != ""
+		keepContainers := os.Getenv("KEEP_CONTAINTERS") != "" || (KeepResourcesOnFailure && t.Failed())
 
 		ctx := context.TODO()
 		cli.NegotiateAPIVersion(ctx)
+		runCleanupHooks(ctx, t)
+
 		cs, err := cli.ContainerList(ctx, types.ContainerListOptions{
 			All: true,
 			Filters: filters.NewArgs(
@@ -127,7 +227,15 @@ func dockerCleanup(t DockerSetupTestingT, cli *client.Client) func() {
 					}
 				}
 			}
-			if !keepContainers {
+			if !keepContainers && !isContainerRunning(c) {
+				// Nothing to stop or wait for; remove the container directly.
+				if err := cli.ContainerRemove(ctx, c.ID, types.ContainerRemoveOptions{
+					// Not removing volumes with the container, because we separately handle them conditionally.
+					Force: true,
+				}); err != nil {
+					t.Logf("Failed to remove container %s during docker cleanup: %v", c.ID, err)
+				}
+			} else if !keepContainers {
 				var stopTimeout container.StopOptions
 				timeout := 10
 				timeoutDur := time.Duration(timeout * int(time.Second))
@@ -163,7 +271,10 @@ func dockerCleanup(t DockerSetupTestingT, cli *client.Client) func() {
 
 		if !keepContainers {
 			pruneVolumesWithRetry(ctx, t, cli)
-			pruneNetworksWithRetry(ctx, t, cli)
+			if !KeepNetworks {
+				pruneNetworksWithRetry(ctx, t, cli)
+				removeLingeringNetworks(ctx, t, cli)
+			}
 		} else {
 			t.Logf("Keeping containers - Docker cleanup skipped")
 		}
@@ -243,6 +354,59 @@ func pruneNetworksWithRetry(ctx context.Context, t DockerSetupTestingT, cli *cli
 	}
 }
 
+// removeLingeringNetworks removes the networks of the test left behind by pruneNetworksWithRetry because they
+// still had active endpoints, retrying for up to NetworkCleanupGracePeriod while the endpoints detach.
+func removeLingeringNetworks(ctx context.Context, t DockerSetupTestingT, cli *client.Client) {
+	networks, err := cli.NetworkList(ctx, types.NetworkListOptions{
+		Filters: filters.NewArgs(filters.Arg("label", CleanupLabel+"="+t.Name())),
+	})
+	if err != nil {
+		t.Logf("Failed to list networks during docker cleanup: %v", err)
+		return
+	}
+
+	for _, n := range networks {
+		ctx, cancel := context.WithTimeout(ctx, NetworkCleanupGracePeriod)
+		err := retry.Do(
+			func() error {
+				err := cli.NetworkRemove(ctx, n.ID)
+				if err == nil || errdefs.IsNotFound(err) {
+					return nil
+				}
+				return err
+			},
+			retry.Context(ctx),
+			retry.Attempts(0),
+			retry.RetryIf(isActiveEndpointsError),
+			retry.Delay(networkRemoveRetryDelay),
+			retry.DelayType(retry.FixedDelay),
+			retry.LastErrorOnly(true),
+		)
+		cancel()
+		if err != nil {
+			t.Logf("Failed to remove network %s during docker cleanup: %v", n.Name, err)
+			continue
+		}
+		t.Logf("Removed network %s after its endpoints detached", n.Name)
+	}
+}
+
+// isActiveEndpointsError reports whether err is the daemon refusing to remove a network that still has endpoints.
+func isActiveEndpointsError(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "active endpoints")
+}
+
+// isContainerRunning reports whether the listed container may still have a running process,
+// i.e. whether it needs to be stopped and waited on before removal.
+func isContainerRunning(c types.Container) bool {
+	switch c.State {
+	case "created", "exited", "dead":
+		return false
+	default:
+		return true
+	}
+}
+
 func isLoggableStopError(err error) bool {
 	if err == nil {
 		return false
@@ -3,8 +3,14 @@ package dockerutil_test
 import (
 	"context"
 	"fmt"
+	"io"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
 	volumetypes "github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/errdefs"
 	"github.com/strangelove-ventures/interchaintest/v8/internal/dockerutil"
@@ -78,3 +84,285 @@ func TestDockerSetup_KeepVolumes(t *testing.T) {
 		})
 	}
 }
+
+func TestDockerSetup_KeepResources(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+
+	cli, _ := dockerutil.DockerSetup(t)
+	ctx := context.Background()
+
+	const image = "busybox:stable"
+	rc, err := cli.ImagePull(ctx, image, types.ImagePullOptions{})
+	require.NoError(t, err)
+	_, _ = io.Copy(io.Discard, rc)
+	_ = rc.Close()
+
+	origKeep := dockerutil.KeepResourcesOnFailure
+	defer func() {
+		dockerutil.KeepResourcesOnFailure = origKeep
+	}()
+
+	for _, tc := range []struct {
+		passed bool
+		kept   bool
+	}{
+		{passed: false, kept: true},
+		{passed: true, kept: false},
+	} {
+		tc := tc
+		t.Run(fmt.Sprintf("passed=%t", tc.passed), func(t *testing.T) {
+			dockerutil.KeepResourcesOnFailure = true
+			mt := mocktesting.NewT(t.Name())
+
+			var containerID, networkID string
+			mt.Simulate(func() {
+				_, networkID = dockerutil.DockerSetup(mt)
+
+				cc, err := cli.ContainerCreate(ctx, &container.Config{
+					Image:  image,
+					Cmd:    []string{"true"},
+					Labels: map[string]string{dockerutil.CleanupLabel: mt.Name()},
+				}, nil, nil, nil, "")
+				require.NoError(t, err)
+				containerID = cc.ID
+
+				if !tc.passed {
+					mt.Fail()
+				}
+			})
+
+			_, containerErr := cli.ContainerInspect(ctx, containerID)
+			_, networkErr := cli.NetworkInspect(ctx, networkID, types.NetworkInspectOptions{})
+			if !tc.kept {
+				require.Truef(t, errdefs.IsNotFound(containerErr), "expected not found error, got %v", containerErr)
+				require.Truef(t, errdefs.IsNotFound(networkErr), "expected not found error, got %v", networkErr)
+				return
+			}
+
+			require.NoError(t, containerErr)
+			require.NoError(t, networkErr)
+			if err := cli.ContainerRemove(ctx, containerID, types.ContainerRemoveOptions{Force: true}); err != nil {
+				t.Logf("failed to remove container %s: %v", containerID, err)
+			}
+			if err := cli.NetworkRemove(ctx, networkID); err != nil {
+				t.Logf("failed to remove network %s: %v", networkID, err)
+			}
+		})
+	}
+}
+
+func TestDockerSetup_KeepNetworks(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+
+	cli, _ := dockerutil.DockerSetup(t)
+	ctx := context.Background()
+
+	const image = "busybox:stable"
+	rc, err := cli.ImagePull(ctx, image, types.ImagePullOptions{})
+	require.NoError(t, err)
+	_, _ = io.Copy(io.Discard, rc)
+	_ = rc.Close()
+
+	origKeep := dockerutil.KeepNetworks
+	defer func() {
+		dockerutil.KeepNetworks = origKeep
+	}()
+	dockerutil.KeepNetworks = true
+
+	mt := mocktesting.NewT(t.Name())
+
+	var containerID, networkID string
+	mt.Simulate(func() {
+		_, networkID = dockerutil.DockerSetup(mt)
+
+		cc, err := cli.ContainerCreate(ctx, &container.Config{
+			Image:  image,
+			Cmd:    []string{"true"},
+			Labels: map[string]string{dockerutil.CleanupLabel: mt.Name()},
+		}, nil, nil, nil, "")
+		require.NoError(t, err)
+		containerID = cc.ID
+	})
+
+	_, err = cli.ContainerInspect(ctx, containerID)
+	require.Truef(t, errdefs.IsNotFound(err), "expected not found error, got %v", err)
+
+	_, err = cli.NetworkInspect(ctx, networkID, types.NetworkInspectOptions{})
+	require.NoError(t, err)
+	if err := cli.NetworkRemove(ctx, networkID); err != nil {
+		t.Logf("failed to remove network %s: %v", networkID, err)
+	}
+}
+
+func TestDockerSetupWithOptions_Labels(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+
+	cli, networkID := dockerutil.DockerSetupWithOptions(t, dockerutil.DockerSetupOptions{
+		Labels: map[string]string{
+			"ci.job-id":             "1234",
+			dockerutil.CleanupLabel: "must-not-override",
+		},
+	})
+
+	network, err := cli.NetworkInspect(context.Background(), networkID, types.NetworkInspectOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "1234", network.Labels["ci.job-id"])
+	require.Equal(t, t.Name(), network.Labels[dockerutil.CleanupLabel])
+}
+
+func TestDockerSetupWithOptions_APIVersion(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+
+	cli, _ := dockerutil.DockerSetupWithOptions(t, dockerutil.DockerSetupOptions{APIVersion: "1.41"})
+	require.Equal(t, "1.41", cli.ClientVersion())
+
+	_, err := cli.Ping(context.Background())
+	require.NoError(t, err)
+}
+
+func TestDockerSetup_CleanupStoppedContainer(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+
+	cli, _ := dockerutil.DockerSetup(t)
+	ctx := context.Background()
+
+	const image = "busybox:stable"
+	rc, err := cli.ImagePull(ctx, image, types.ImagePullOptions{})
+	require.NoError(t, err)
+	_, _ = io.Copy(io.Discard, rc)
+	_ = rc.Close()
+
+	mt := mocktesting.NewT(t.Name())
+
+	var (
+		containerID     string
+		cleanupStart    time.Time
+		cleanupDuration time.Duration
+	)
+	mt.Simulate(func() {
+		// Cleanups run in reverse order, so this runs after the docker cleanup registered by DockerSetup.
+		mt.Cleanup(func() {
+			cleanupDuration = time.Since(cleanupStart)
+		})
+
+		dockerutil.DockerSetup(mt)
+
+		// A container that was created but never started is already in a not-running state.
+		cc, err := cli.ContainerCreate(ctx, &container.Config{
+			Image:  image,
+			Cmd:    []string{"true"},
+			Labels: map[string]string{dockerutil.CleanupLabel: mt.Name()},
+		}, nil, nil, nil, "")
+		require.NoError(t, err)
+		containerID = cc.ID
+
+		// And this runs before the docker cleanup.
+		mt.Cleanup(func() {
+			cleanupStart = time.Now()
+		})
+	})
+
+	require.Less(t, cleanupDuration, 5*time.Second)
+
+	_, err = cli.ContainerInspect(ctx, containerID)
+	require.Truef(t, errdefs.IsNotFound(err), "expected not found error, got %v", err)
+}
+
+func TestDockerSetupWithOptions_SkipEagerCleanup(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+
+	cli, _ := dockerutil.DockerSetup(t)
+	ctx := context.Background()
+
+	const image = "busybox:stable"
+	rc, err := cli.ImagePull(ctx, image, types.ImagePullOptions{})
+	require.NoError(t, err)
+	_, _ = io.Copy(io.Discard, rc)
+	_ = rc.Close()
+
+	for _, skip := range []bool{true, false} {
+		mt := mocktesting.NewT(t.Name())
+
+		mt.Simulate(func() {
+			// A container left over from, or belonging to a concurrent run of, a test with the same name.
+			cc, err := cli.ContainerCreate(ctx, &container.Config{
+				Image:  image,
+				Cmd:    []string{"true"},
+				Labels: map[string]string{dockerutil.CleanupLabel: mt.Name()},
+			}, nil, nil, nil, "")
+			require.NoError(t, err)
+
+			_, _ = dockerutil.DockerSetupWithOptions(mt, dockerutil.DockerSetupOptions{SkipEagerCleanup: skip})
+
+			_, err = cli.ContainerInspect(ctx, cc.ID)
+			if skip {
+				require.NoError(t, err)
+			} else {
+				require.Truef(t, errdefs.IsNotFound(err), "expected not found error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestDockerSetup_NetworkCleanupGracePeriod(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+
+	cli, _ := dockerutil.DockerSetup(t)
+	ctx := context.Background()
+
+	const image = "busybox:stable"
+	rc, err := cli.ImagePull(ctx, image, types.ImagePullOptions{})
+	require.NoError(t, err)
+	_, _ = io.Copy(io.Discard, rc)
+	_ = rc.Close()
+
+	mt := mocktesting.NewT(t.Name())
+
+	var networkID string
+	mt.Simulate(func() {
+		_, networkID = dockerutil.DockerSetup(mt)
+
+		// A container that is not cleaned up with the test keeps an endpoint on the network
+		// until it is removed a moment after cleanup starts.
+		cc, err := cli.ContainerCreate(ctx, &container.Config{
+			Image: image,
+			Cmd:   []string{"sleep", "60"},
+		}, nil, &network.NetworkingConfig{
+			EndpointsConfig: map[string]*network.EndpointSettings{networkID: {}},
+		}, nil, "")
+		require.NoError(t, err)
+		require.NoError(t, cli.ContainerStart(ctx, cc.ID, types.ContainerStartOptions{}))
+
+		mt.Cleanup(func() {
+			go func() {
+				time.Sleep(2 * time.Second)
+				_ = cli.ContainerRemove(ctx, cc.ID, types.ContainerRemoveOptions{Force: true})
+			}()
+		})
+	})
+
+	_, err = cli.NetworkInspect(ctx, networkID, types.NetworkInspectOptions{})
+	require.Truef(t, errdefs.IsNotFound(err), "expected not found error, got %v", err)
+	require.Condition(t, func() bool {
+		for _, log := range mt.Logs {
+			if strings.HasSuffix(log, "after its endpoints detached") {
+				return true
+			}
+		}
+		return false
+	}, "expected network to be removed after the grace period, logs: %v", mt.Logs)
+}
@@ -86,3 +86,16 @@ func TestSanitizeContainerName(t *testing.T) {
 		require.Equal(t, tt.Want, SanitizeContainerName(tt.Name), tt)
 	}
 }
+
+func TestMergeLabels(t *testing.T) {
+	base := map[string]string{CleanupLabel: "TestFoo"}
+	merged := MergeLabels(base, map[string]string{
+		CleanupLabel: "override-attempt",
+		"ci.job-id":  "1234",
+	})
+
+	require.Equal(t, map[string]string{CleanupLabel: "TestFoo", "ci.job-id": "1234"}, merged)
+	require.Len(t, base, 1, "base labels must not be modified")
+
+	require.Equal(t, base, MergeLabels(base, nil))
+}
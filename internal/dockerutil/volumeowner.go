@@ -3,6 +3,7 @@ package dockerutil
 import (
 	"context"
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/docker/docker/api/types"
@@ -21,6 +22,10 @@ type VolumeOwnerOptions struct {
 	ImageRef   string
 	TestName   string
 	UidGid     string
+
+	// Mode is the permission mode applied to the volume root.
+	// If zero, 0700 is used.
+	Mode os.FileMode
 }
 
 // SetVolumeOwner configures the owner of a volume to match the default user in the supplied image reference.
@@ -30,6 +35,11 @@ func SetVolumeOwner(ctx context.Context, opts VolumeOwnerOptions) error {
 		owner = GetRootUserString()
 	}
 
+	mode := opts.Mode
+	if mode == 0 {
+		mode = 0700
+	}
+
 	// Start a one-off container to chmod and chown the volume.
 
 	containerName := fmt.Sprintf("interchaintest-volumeowner-%d-%s", time.Now().UnixNano(), RandLowerCaseLetterString(5))
@@ -46,10 +56,11 @@ func SetVolumeOwner(ctx context.Context, opts VolumeOwnerOptions) error {
 
 			Entrypoint: []string{"sh", "-c"},
 			Cmd: []string{
-				`chown "$2" "$1" && chmod 0700 "$1"`,
+				`chown "$2" "$1" && chmod "$3" "$1"`,
 				"_", // Meaningless arg0 for sh -c with positional args.
 				mountPath,
 				owner,
+				fmt.Sprintf("%04o", mode.Perm()),
 			},
 
 			// Root user so we have permissions to set ownership and mode.
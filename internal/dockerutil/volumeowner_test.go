@@ -0,0 +1,69 @@
+package dockerutil_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	volumetypes "github.com/docker/docker/api/types/volume"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v8"
+	"github.com/strangelove-ventures/interchaintest/v8/internal/dockerutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestSetVolumeOwner_Mode(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+
+	t.Parallel()
+
+	cli, network := interchaintest.DockerSetup(t)
+
+	ctx := context.Background()
+	testName := t.Name()
+
+	img := dockerutil.NewImage(
+		zaptest.NewLogger(t),
+		cli,
+		network,
+		t.Name(),
+		"busybox", "stable",
+	)
+
+	for _, tc := range []struct {
+		name string
+		opts dockerutil.VolumeOwnerOptions
+		want string
+	}{
+		{name: "default", want: "700"},
+		{name: "custom", opts: dockerutil.VolumeOwnerOptions{Mode: 0755}, want: "755"},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			v, err := cli.VolumeCreate(ctx, volumetypes.CreateOptions{
+				Labels: map[string]string{dockerutil.CleanupLabel: testName},
+			})
+			require.NoError(t, err)
+
+			opts := tc.opts
+			opts.Log = zaptest.NewLogger(t)
+			opts.Client = cli
+			opts.VolumeName = v.Name
+			opts.TestName = testName
+			require.NoError(t, dockerutil.SetVolumeOwner(ctx, opts))
+
+			res := img.Run(
+				ctx,
+				[]string{"stat", "-c", "%a", "/mnt/test"},
+				dockerutil.ContainerOptions{
+					Binds: []string{v.Name + ":/mnt/test"},
+					User:  dockerutil.GetRootUserString(),
+				},
+			)
+			require.NoError(t, res.Err)
+			require.Equal(t, tc.want, strings.TrimSpace(string(res.Stdout)))
+		})
+	}
+}
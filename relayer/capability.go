@@ -1,5 +1,12 @@
 package relayer
 
+import (
+	"errors"
+	"fmt"
+
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+)
+
 //go:generate go run golang.org/x/tools/cmd/stringer -type=Capability
 
 // While the relayer capability type may have made a little more sense inside the interchaintest package,
@@ -31,3 +38,23 @@ func FullCapabilities() map[Capability]bool {
 		Flush: true,
 	}
 }
+
+// ErrUnsupportedCapability is returned when an operation depends on a capability that the relayer does not support.
+var ErrUnsupportedCapability = errors.New("unsupported relayer capability")
+
+// CheckTransferOptions returns an error wrapping ErrUnsupportedCapability
+// if a transfer sent with opts could time out in a way the relayer with the given capabilities cannot relay.
+// Callers can check this before sending a transfer so that the test skips or fails early
+// rather than waiting on a timeout that is never relayed.
+func CheckTransferOptions(caps map[Capability]bool, opts ibc.TransferOptions) error {
+	if opts.Timeout == nil {
+		return nil
+	}
+	if opts.Timeout.NanoSeconds > 0 && !caps[TimestampTimeout] {
+		return fmt.Errorf("transfer with timestamp timeout: %w: %s", ErrUnsupportedCapability, TimestampTimeout)
+	}
+	if opts.Timeout.Height > 0 && !caps[HeightTimeout] {
+		return fmt.Errorf("transfer with height timeout: %w: %s", ErrUnsupportedCapability, HeightTimeout)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package relayer
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"go.uber.org/multierr"
+
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+)
+
+// probeScript opens a TCP connection to the host and port given as its arguments.
+// Relayer images do not agree on the available tools, so nc is preferred and bash's /dev/tcp is the fallback.
+const probeScript = `if command -v nc >/dev/null 2>&1; then nc -z -w 5 "$1" "$2"; else timeout 5 bash -c 'exec 3<>/dev/tcp/$0/$1' "$1" "$2"; fi`
+
+// CheckConnectivity verifies that the RPC and gRPC endpoints of a chain can be reached from a relayer container,
+// using the same docker network and name resolution as the relayer commands.
+// The returned error names every endpoint that could not be reached. An empty address is not checked.
+func (r *DockerRelayer) CheckConnectivity(ctx context.Context, rpcAddr, grpcAddr string) error {
+	var merr error
+	for _, endpoint := range []struct{ kind, addr string }{{"rpc", rpcAddr}, {"grpc", grpcAddr}} {
+		if endpoint.addr == "" {
+			continue
+		}
+		if err := r.probe(ctx, endpoint.addr); err != nil {
+			multierr.AppendInto(&merr, fmt.Errorf("%s endpoint %s unreachable from relayer container: %w", endpoint.kind, endpoint.addr, err))
+		}
+	}
+	return merr
+}
+
+func (r *DockerRelayer) probe(ctx context.Context, addr string) error {
+	host, port, err := splitEndpoint(addr)
+	if err != nil {
+		return err
+	}
+	res := r.Exec(ctx, ibc.NopRelayerExecReporter{}, []string{"sh", "-c", probeScript, "probe", host, port}, nil)
+	if res.Err != nil {
+		if stderr := strings.TrimSpace(string(res.Stderr)); stderr != "" {
+			return fmt.Errorf("%w: %s", res.Err, stderr)
+		}
+		return res.Err
+	}
+	return nil
+}
+
+// splitEndpoint returns the host and port of an address given either as a URL, e.g. http://gaia-val-0:26657,
+// or as host:port, e.g. gaia-val-0:9090. A URL without a port uses the default port of its scheme.
+func splitEndpoint(addr string) (host, port string, err error) {
+	if !strings.Contains(addr, "://") {
+		host, port, err = net.SplitHostPort(addr)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid address %q: %w", addr, err)
+		}
+		return host, port, nil
+	}
+
+	u, err := url.Parse(addr)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid address %q: %w", addr, err)
+	}
+	host, port = u.Hostname(), u.Port()
+	if port == "" {
+		switch u.Scheme {
+		case "http", "ws":
+			port = "80"
+		case "https", "wss":
+			port = "443"
+		default:
+			return "", "", fmt.Errorf("invalid address %q: missing port", addr)
+		}
+	}
+	return host, port, nil
+}
@@ -3,17 +3,23 @@ package relayer
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"os"
 	"path"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
 	volumetypes "github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
 	"github.com/docker/docker/pkg/stdcopy"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapio"
 
 	"github.com/strangelove-ventures/interchaintest/v8/ibc"
 	"github.com/strangelove-ventures/interchaintest/v8/internal/dockerutil"
@@ -39,7 +45,38 @@ type DockerRelayer struct {
 	testName string
 
 	customImage *ibc.DockerImage
-	pullImage   bool
+	pullPolicy  PullPolicy
+
+	// labels are user supplied docker labels attached to created resources.
+	labels map[string]string
+
+	// extraHosts and dns configure name resolution in the relayer containers.
+	extraHosts []string
+	dns        []string
+
+	// resources limits the relayer containers.
+	resources container.Resources
+
+	// env contains user supplied environment variables set in the relayer containers.
+	env []string
+
+	// hostMounts are host paths mounted into the relayer containers.
+	hostMounts []hostMount
+
+	// restartPolicy applies to the container created by StartRelayer.
+	restartPolicy container.RestartPolicy
+
+	// logConfig sets the logging driver of the container created by StartRelayer.
+	logConfig container.LogConfig
+
+	// artifactsDir, if set, is where the home directory is copied when the docker resources of the test are cleaned up.
+	artifactsDir string
+
+	// startupCheck, if non-zero, is how long StartRelayer watches the relayer for startup failures.
+	startupCheck time.Duration
+
+	// commandTimeouts contains a mapping of RelayerCommander method name to the user supplied timeout of its command.
+	commandTimeouts map[string]time.Duration
 
 	// The ID of the container created by StartRelayer.
 	containerLifecycle *dockerutil.ContainerLifecycle
@@ -47,13 +84,55 @@ type DockerRelayer struct {
 	// wallets contains a mapping of chainID to relayer wallet
 	wallets map[string]ibc.Wallet
 
-	homeDir string
+	// bech32Prefixes contains a mapping of chainID to the address prefix of the chains configured so far.
+	bech32Prefixes map[string]string
+
+	homeDir     string
+	homeDirMode os.FileMode
 
 	extraStartupFlags []string
+
+	// execOutput and startOutput control the handling of container output.
+	execOutput  OutputMode
+	startOutput OutputMode
+
+	// executor, if set, runs commands in place of one-off docker containers.
+	executor Executor
 }
 
 var _ ibc.Relayer = (*DockerRelayer)(nil)
 
+// Executor runs a relayer command and returns its result, in place of running it in a docker container.
+type Executor func(ctx context.Context, cmd []string, env []string) ibc.RelayerExecResult
+
+// NewDockerRelayerWithExecutor returns a DockerRelayer that does not use docker to run commands.
+// Instead, every command generated by c is passed to exec, so that the command generation and
+// output parsing of a relayer can be tested in isolation.
+//
+// No docker resources are created, so only methods that run commands through Exec are supported.
+// In particular, the relayer cannot be started and its home directory cannot be read or written.
+func NewDockerRelayerWithExecutor(log *zap.Logger, testName string, c RelayerCommander, exec Executor, options ...RelayerOpt) *DockerRelayer {
+	r := &DockerRelayer{
+		log: log,
+
+		c: c,
+
+		testName: testName,
+
+		wallets: map[string]ibc.Wallet{},
+
+		executor: exec,
+	}
+
+	r.homeDir = defaultRlyHomeDirectory
+
+	for _, opt := range options {
+		opt(r)
+	}
+
+	return r
+}
+
 // NewDockerRelayer returns a new DockerRelayer.
 func NewDockerRelayer(ctx context.Context, log *zap.Logger, testName string, cli *client.Client, networkID string, c RelayerCommander, options ...RelayerOpt) (*DockerRelayer, error) {
 	r := DockerRelayer{
@@ -64,9 +143,6 @@ func NewDockerRelayer(ctx context.Context, log *zap.Logger, testName string, cli
 		networkID: networkID,
 		client:    cli,
 
-		// pull true by default, can be overridden with options
-		pullImage: true,
-
 		testName: testName,
 
 		wallets: map[string]ibc.Wallet{},
@@ -78,15 +154,22 @@ func NewDockerRelayer(ctx context.Context, log *zap.Logger, testName string, cli
 		opt(&r)
 	}
 
+	if err := r.resolveHostMounts(); err != nil {
+		return nil, err
+	}
+
 	containerImage := r.ContainerImage()
-	if err := r.pullContainerImageIfNecessary(containerImage); err != nil {
+	if err := r.pullContainerImageIfNecessary(ctx, containerImage); err != nil {
 		return nil, fmt.Errorf("pulling container image %s: %w", containerImage.Ref(), err)
 	}
 
 	v, err := cli.VolumeCreate(ctx, volumetypes.CreateOptions{
 		// Have to leave Driver unspecified for Docker Desktop compatibility.
 
-		Labels: map[string]string{dockerutil.CleanupLabel: testName},
+		Labels: dockerutil.MergeLabels(map[string]string{
+			dockerutil.CleanupLabel:      testName,
+			dockerutil.RelayerImageLabel: containerImage.Ref(),
+		}, r.labels),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("creating volume: %w", err)
@@ -106,10 +189,15 @@ func NewDockerRelayer(ctx context.Context, log *zap.Logger, testName string, cli
 		ImageRef:   containerImage.Ref(),
 		TestName:   testName,
 		UidGid:     containerImage.UidGid,
+		Mode:       r.homeDirMode,
 	}); err != nil {
 		return nil, fmt.Errorf("set volume owner: %w", err)
 	}
 
+	if r.artifactsDir != "" {
+		dockerutil.RegisterCleanupHook(testName, r.preserveHomeDir)
+	}
+
 	if init := r.c.Init(r.HomeDir()); len(init) > 0 {
 		// Initialization should complete immediately,
 		// but add a 1-minute timeout in case Docker hangs on a developer workstation.
@@ -127,9 +215,23 @@ func NewDockerRelayer(ctx context.Context, log *zap.Logger, testName string, cli
 	return &r, nil
 }
 
+// ErrNoDocker is returned by methods that need docker when the relayer was created through NewDockerRelayerWithExecutor.
+var ErrNoDocker = errors.New("relayer has no docker client")
+
+// requireDocker returns ErrNoDocker if the relayer cannot access docker resources such as its home directory volume.
+func (r *DockerRelayer) requireDocker() error {
+	if r.client == nil {
+		return ErrNoDocker
+	}
+	return nil
+}
+
 // WriteFileToHomeDir writes the given contents to a file at the relative path specified. The file is relative
 // to the home directory in the relayer container.
 func (r *DockerRelayer) WriteFileToHomeDir(ctx context.Context, relativePath string, contents []byte) error {
+	if err := r.requireDocker(); err != nil {
+		return err
+	}
 	fw := dockerutil.NewFileWriter(r.log, r.client, r.testName)
 	if err := fw.WriteFile(ctx, r.volumeName, relativePath, contents); err != nil {
 		return fmt.Errorf("failed to write file: %w", err)
@@ -140,6 +242,9 @@ func (r *DockerRelayer) WriteFileToHomeDir(ctx context.Context, relativePath str
 // ReadFileFromHomeDir reads a file at the relative path specified and returns the contents. The file is
 // relative to the home directory in the relayer container.
 func (r *DockerRelayer) ReadFileFromHomeDir(ctx context.Context, relativePath string) ([]byte, error) {
+	if err := r.requireDocker(); err != nil {
+		return nil, err
+	}
 	fr := dockerutil.NewFileRetriever(r.log, r.client, r.testName)
 	bytes, err := fr.SingleFileContent(ctx, r.volumeName, relativePath)
 	if err != nil {
@@ -148,6 +253,36 @@ func (r *DockerRelayer) ReadFileFromHomeDir(ctx context.Context, relativePath st
 	return bytes, nil
 }
 
+// ExportState returns a tar archive of the entire relayer home directory, including config files and keys,
+// so that a failing test's relayer state can be reproduced offline.
+// The archive is read from the home directory volume, so it can be taken whether or not the relayer is running.
+func (r *DockerRelayer) ExportState(ctx context.Context) ([]byte, error) {
+	if err := r.requireDocker(); err != nil {
+		return nil, err
+	}
+	fr := dockerutil.NewFileRetriever(r.log, r.client, r.testName)
+	bz, err := fr.Archive(ctx, r.volumeName, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to export relayer home directory: %w", err)
+	}
+	return bz, nil
+}
+
+// preserveHomeDir copies the home directory into a subdirectory of the artifacts directory named after the relayer
+// and its volume, so that the home directories of several relayers of a test can be kept side by side.
+func (r *DockerRelayer) preserveHomeDir(ctx context.Context) error {
+	bz, err := r.ExportState(ctx)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Join(r.artifactsDir, r.Name()+"-"+r.volumeName[:min(12, len(r.volumeName))])
+	if err := dockerutil.ExtractArchive(bz, dir); err != nil {
+		return fmt.Errorf("failed to preserve relayer home directory in %s: %w", dir, err)
+	}
+	r.log.Info("Preserved relayer home directory", zap.String("dir", dir))
+	return nil
+}
+
 // Modify a toml config file in relayer home directory
 func (r *DockerRelayer) ModifyTomlConfigFile(ctx context.Context, relativePath string, modification testutil.Toml) error {
 	return testutil.ModifyTomlConfigFile(ctx, r.log, r.client, r.testName, r.volumeName, relativePath, modification)
@@ -175,26 +310,38 @@ func (r *DockerRelayer) AddChainConfiguration(ctx context.Context, rep ibc.Relay
 		return fmt.Errorf("failed to rly config: %w", err)
 	}
 
-	cmd := r.c.AddChainConfiguration(chainConfigContainerFilePath, r.HomeDir())
+	cmd, err := r.command("AddChainConfiguration", func() ([]string, error) { return r.c.AddChainConfiguration(chainConfigContainerFilePath, r.HomeDir()) })
+	if err != nil {
+		return err
+	}
 
-	// Adding the chain configuration simply reads from a file on disk,
-	// so this should also complete immediately.
-	ctx, cancel := context.WithTimeout(ctx, time.Minute)
-	defer cancel()
+	res := r.execCommand(ctx, rep, "AddChainConfiguration", cmd)
+	if res.Err != nil {
+		return res.Err
+	}
+	r.recordBech32Prefix(chainConfig.ChainID, chainConfig.Bech32Prefix)
+	return nil
+}
 
-	res := r.Exec(ctx, rep, cmd, nil)
-	return res.Err
+// recordBech32Prefix tracks the address prefix of a configured chain, so that the addresses of keys added for it
+// can be validated.
+func (r *DockerRelayer) recordBech32Prefix(chainID, prefix string) {
+	if r.bech32Prefixes == nil {
+		r.bech32Prefixes = map[string]string{}
+	}
+	r.bech32Prefixes[chainID] = prefix
 }
 
+// AddKey creates a new key for the given chain and returns its wallet.
+// If the chain was configured through AddChainConfiguration with a bech32 prefix, the address of the new key
+// must be a valid bech32 address with that prefix, otherwise an error is returned.
 func (r *DockerRelayer) AddKey(ctx context.Context, rep ibc.RelayerExecReporter, chainID, keyName, coinType string) (ibc.Wallet, error) {
-	cmd := r.c.AddKey(chainID, keyName, coinType, r.HomeDir())
-
-	// Adding a key should be near-instantaneous, so add a 1-minute timeout
-	// to detect if Docker has hung.
-	ctx, cancel := context.WithTimeout(ctx, time.Minute)
-	defer cancel()
+	cmd, err := r.command("AddKey", func() ([]string, error) { return r.c.AddKey(chainID, keyName, coinType, r.HomeDir()) })
+	if err != nil {
+		return nil, err
+	}
 
-	res := r.Exec(ctx, rep, cmd, nil)
+	res := r.execCommand(ctx, rep, "AddKey", cmd)
 	if res.Err != nil {
 		return nil, res.Err
 	}
@@ -203,6 +350,11 @@ func (r *DockerRelayer) AddKey(ctx context.Context, rep ibc.RelayerExecReporter,
 	if err != nil {
 		return nil, err
 	}
+	if prefix := r.bech32Prefixes[chainID]; prefix != "" {
+		if err := CheckAddressPrefix(wallet.FormattedAddress(), prefix); err != nil {
+			return nil, fmt.Errorf("key %s added for chain %s: %w", keyName, chainID, err)
+		}
+	}
 	r.wallets[chainID] = wallet
 	return wallet, nil
 }
@@ -217,49 +369,66 @@ func (r *DockerRelayer) GetWallet(chainID string) (ibc.Wallet, bool) {
 }
 
 func (r *DockerRelayer) CreateChannel(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, opts ibc.CreateChannelOptions) error {
-	cmd := r.c.CreateChannel(pathName, opts, r.HomeDir())
-	res := r.Exec(ctx, rep, cmd, nil)
+	cmd, err := r.command("CreateChannel", func() ([]string, error) { return r.c.CreateChannel(pathName, opts, r.HomeDir()) })
+	if err != nil {
+		return err
+	}
+	res := r.execCommand(ctx, rep, "CreateChannel", cmd)
 	return res.Err
 }
 
 func (r *DockerRelayer) CreateClients(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, opts ibc.CreateClientOptions) error {
-	cmd := r.c.CreateClients(pathName, opts, r.HomeDir())
-	res := r.Exec(ctx, rep, cmd, nil)
+	cmd, err := r.command("CreateClients", func() ([]string, error) { return r.c.CreateClients(pathName, opts, r.HomeDir()) })
+	if err != nil {
+		return err
+	}
+	res := r.execCommand(ctx, rep, "CreateClients", cmd)
 	return res.Err
 }
 
 func (r *DockerRelayer) CreateConnections(ctx context.Context, rep ibc.RelayerExecReporter, pathName string) error {
-	cmd := r.c.CreateConnections(pathName, r.HomeDir())
-	res := r.Exec(ctx, rep, cmd, nil)
+	cmd, err := r.command("CreateConnections", func() ([]string, error) { return r.c.CreateConnections(pathName, r.HomeDir()) })
+	if err != nil {
+		return err
+	}
+	res := r.execCommand(ctx, rep, "CreateConnections", cmd)
 	return res.Err
 }
 
 func (r *DockerRelayer) Flush(ctx context.Context, rep ibc.RelayerExecReporter, pathName, channelID string) error {
-	cmd := r.c.Flush(pathName, channelID, r.HomeDir())
-	res := r.Exec(ctx, rep, cmd, nil)
+	cmd, err := r.command("Flush", func() ([]string, error) { return r.c.Flush(pathName, channelID, r.HomeDir()) })
+	if err != nil {
+		return err
+	}
+	res := r.execCommand(ctx, rep, "Flush", cmd)
 	return res.Err
 }
 
 func (r *DockerRelayer) GeneratePath(ctx context.Context, rep ibc.RelayerExecReporter, srcChainID, dstChainID, pathName string) error {
-	cmd := r.c.GeneratePath(srcChainID, dstChainID, pathName, r.HomeDir())
-	res := r.Exec(ctx, rep, cmd, nil)
+	cmd, err := r.command("GeneratePath", func() ([]string, error) { return r.c.GeneratePath(srcChainID, dstChainID, pathName, r.HomeDir()) })
+	if err != nil {
+		return err
+	}
+	res := r.execCommand(ctx, rep, "GeneratePath", cmd)
 	return res.Err
 }
 
 func (r *DockerRelayer) UpdatePath(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, filter ibc.ChannelFilter) error {
-	cmd := r.c.UpdatePath(pathName, r.HomeDir(), filter)
-	res := r.Exec(ctx, rep, cmd, nil)
+	cmd, err := r.command("UpdatePath", func() ([]string, error) { return r.c.UpdatePath(pathName, r.HomeDir(), filter) })
+	if err != nil {
+		return err
+	}
+	res := r.execCommand(ctx, rep, "UpdatePath", cmd)
 	return res.Err
 }
 
 func (r *DockerRelayer) GetChannels(ctx context.Context, rep ibc.RelayerExecReporter, chainID string) ([]ibc.ChannelOutput, error) {
-	cmd := r.c.GetChannels(chainID, r.HomeDir())
-
-	// Getting channels should be very quick, but go up to a 3-minute timeout just in case.
-	ctx, cancel := context.WithTimeout(ctx, 3*time.Minute)
-	defer cancel()
+	cmd, err := r.command("GetChannels", func() ([]string, error) { return r.c.GetChannels(chainID, r.HomeDir()) })
+	if err != nil {
+		return nil, err
+	}
 
-	res := r.Exec(ctx, rep, cmd, nil)
+	res := r.execCommand(ctx, rep, "GetChannels", cmd)
 	if res.Err != nil {
 		return nil, res.Err
 	}
@@ -268,8 +437,11 @@ func (r *DockerRelayer) GetChannels(ctx context.Context, rep ibc.RelayerExecRepo
 }
 
 func (r *DockerRelayer) GetConnections(ctx context.Context, rep ibc.RelayerExecReporter, chainID string) (ibc.ConnectionOutputs, error) {
-	cmd := r.c.GetConnections(chainID, r.HomeDir())
-	res := r.Exec(ctx, rep, cmd, nil)
+	cmd, err := r.command("GetConnections", func() ([]string, error) { return r.c.GetConnections(chainID, r.HomeDir()) })
+	if err != nil {
+		return nil, err
+	}
+	res := r.execCommand(ctx, rep, "GetConnections", cmd)
 	if res.Err != nil {
 		return nil, res.Err
 	}
@@ -278,8 +450,11 @@ func (r *DockerRelayer) GetConnections(ctx context.Context, rep ibc.RelayerExecR
 }
 
 func (r *DockerRelayer) GetClients(ctx context.Context, rep ibc.RelayerExecReporter, chainID string) (ibc.ClientOutputs, error) {
-	cmd := r.c.GetClients(chainID, r.HomeDir())
-	res := r.Exec(ctx, rep, cmd, nil)
+	cmd, err := r.command("GetClients", func() ([]string, error) { return r.c.GetClients(chainID, r.HomeDir()) })
+	if err != nil {
+		return nil, err
+	}
+	res := r.execCommand(ctx, rep, "GetClients", cmd)
 	if res.Err != nil {
 		return nil, res.Err
 	}
@@ -288,26 +463,130 @@ func (r *DockerRelayer) GetClients(ctx context.Context, rep ibc.RelayerExecRepor
 }
 
 func (r *DockerRelayer) LinkPath(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, channelOpts ibc.CreateChannelOptions, clientOpts ibc.CreateClientOptions) error {
-	cmd := r.c.LinkPath(pathName, r.HomeDir(), channelOpts, clientOpts)
-	res := r.Exec(ctx, rep, cmd, nil)
+	cmd, err := r.command("LinkPath", func() ([]string, error) { return r.c.LinkPath(pathName, r.HomeDir(), channelOpts, clientOpts) })
+	if err != nil {
+		return err
+	}
+	res := r.execCommand(ctx, rep, "LinkPath", cmd)
 	return res.Err
 }
 
+// ErrInvalidCommand is returned when a RelayerCommander cannot produce a command,
+// e.g. because a path or chain it refers to cannot be resolved.
+var ErrInvalidCommand = errors.New("invalid relayer command")
+
+// command builds a command through the RelayerCommander. A commander that cannot produce the command, or returns
+// an empty command, results in an error wrapping ErrInvalidCommand, rather than a malformed command failing
+// cryptically in the container.
+func (r *DockerRelayer) command(name string, build func() ([]string, error)) ([]string, error) {
+	cmd, err := build()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %w", name, ErrInvalidCommand, err)
+	}
+	if len(cmd) == 0 {
+		return nil, fmt.Errorf("%s: %w: empty command", name, ErrInvalidCommand)
+	}
+	return cmd, nil
+}
+
+const (
+	// handshakeCommandTimeout is the default timeout of commands that submit transactions and wait for them
+	// on both chains of a path, such as handshakes and flushing packets.
+	handshakeCommandTimeout = 10 * time.Minute
+	// queryCommandTimeout is the default timeout of commands that query a chain.
+	queryCommandTimeout = 3 * time.Minute
+	// localCommandTimeout is the default timeout of commands that only touch the relayer's home directory,
+	// which should complete immediately unless docker has hung.
+	localCommandTimeout = time.Minute
+)
+
+// defaultCommandTimeouts contains a mapping of RelayerCommander method name to the default timeout of its command.
+var defaultCommandTimeouts = map[string]time.Duration{
+	"CreateChannel":     handshakeCommandTimeout,
+	"CreateClients":     handshakeCommandTimeout,
+	"CreateConnections": handshakeCommandTimeout,
+	"LinkPath":          handshakeCommandTimeout,
+	"UpdateClients":     handshakeCommandTimeout,
+	"Flush":             handshakeCommandTimeout,
+
+	"GetChannels":    queryCommandTimeout,
+	"GetConnections": queryCommandTimeout,
+	"GetClients":     queryCommandTimeout,
+
+	"AddChainConfiguration": localCommandTimeout,
+	"AddKey":                localCommandTimeout,
+	"RestoreKey":            localCommandTimeout,
+	"GeneratePath":          localCommandTimeout,
+	"UpdatePath":            localCommandTimeout,
+}
+
+// commandTimeout returns the timeout of the command built by the named RelayerCommander method,
+// as overridden through the CommandTimeout option.
+func (r *DockerRelayer) commandTimeout(name string) time.Duration {
+	if timeout, ok := r.commandTimeouts[name]; ok {
+		return timeout
+	}
+	if timeout, ok := defaultCommandTimeouts[name]; ok {
+		return timeout
+	}
+	return handshakeCommandTimeout
+}
+
+// execCommand runs the command built by the named RelayerCommander method, cancelling it once its timeout elapses.
+func (r *DockerRelayer) execCommand(ctx context.Context, rep ibc.RelayerExecReporter, name string, cmd []string) ibc.RelayerExecResult {
+	timeout := r.commandTimeout(name)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	res := r.Exec(ctx, rep, cmd, nil)
+	if res.Err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		res.Err = fmt.Errorf("%s: timed out after %s: %w", name, timeout, res.Err)
+	}
+	return res
+}
+
+// Exec runs the command in a one-off relayer container, or through the executor of the relayer.
+// A command is not run once ctx is done, so that operations made of several commands abort at the next one
+// when they are cancelled.
 func (r *DockerRelayer) Exec(ctx context.Context, rep ibc.RelayerExecReporter, cmd []string, env []string) ibc.RelayerExecResult {
+	if err := ctx.Err(); err != nil {
+		return ibc.RelayerExecResult{Err: fmt.Errorf("relayer command %q not run: %w", strings.Join(cmd, " "), err)}
+	}
+	env = r.containerEnv(env)
+	if r.executor != nil {
+		startedAt := time.Now()
+		res := r.executor(ctx, cmd, env)
+		rep.TrackRelayerExec(r.Name(), cmd, string(res.Stdout), string(res.Stderr), res.ExitCode, startedAt, time.Now(), res.Err)
+		return res
+	}
+
 	job := dockerutil.NewImage(r.log, r.client, r.networkID, r.testName, r.ContainerImage().Repository, r.ContainerImage().Version)
 	opts := dockerutil.ContainerOptions{
-		Env:   env,
-		Binds: r.Bind(),
+		Env:        env,
+		Binds:      r.Bind(),
+		Labels:     r.labels,
+		ExtraHosts: r.extraHosts,
+		DNS:        r.dns,
+		Resources:  r.resources,
+	}
+	if r.execOutput == OutputStream {
+		w := r.logWriter(zap.String("command", strings.Join(cmd, " ")))
+		defer func() { _ = w.Close() }()
+		opts.Stream = w
 	}
 
 	startedAt := time.Now()
 	res := job.Run(ctx, cmd, opts)
 
 	defer func() {
+		stdout, stderr := string(res.Stdout), string(res.Stderr)
+		if r.execOutput == OutputDiscard {
+			stdout, stderr = "", ""
+		}
 		rep.TrackRelayerExec(
 			r.Name(),
 			cmd,
-			string(res.Stdout), string(res.Stderr),
+			stdout, stderr,
 			res.ExitCode,
 			startedAt, time.Now(),
 			res.Err,
@@ -325,14 +604,12 @@ func (r *DockerRelayer) Exec(ctx context.Context, rep ibc.RelayerExecReporter, c
 func (r *DockerRelayer) RestoreKey(ctx context.Context, rep ibc.RelayerExecReporter, cfg ibc.ChainConfig, keyName, mnemonic string) error {
 	chainID := cfg.ChainID
 	coinType := cfg.CoinType
-	cmd := r.c.RestoreKey(chainID, keyName, coinType, mnemonic, r.HomeDir())
-
-	// Restoring a key should be near-instantaneous, so add a 1-minute timeout
-	// to detect if Docker has hung.
-	ctx, cancel := context.WithTimeout(ctx, time.Minute)
-	defer cancel()
+	cmd, err := r.command("RestoreKey", func() ([]string, error) { return r.c.RestoreKey(chainID, keyName, coinType, mnemonic, r.HomeDir()) })
+	if err != nil {
+		return err
+	}
 
-	res := r.Exec(ctx, rep, cmd, nil)
+	res := r.execCommand(ctx, rep, "RestoreKey", cmd)
 	if res.Err != nil {
 		return res.Err
 	}
@@ -344,8 +621,11 @@ func (r *DockerRelayer) RestoreKey(ctx context.Context, rep ibc.RelayerExecRepor
 }
 
 func (r *DockerRelayer) UpdateClients(ctx context.Context, rep ibc.RelayerExecReporter, pathName string) error {
-	cmd := r.c.UpdateClients(pathName, r.HomeDir())
-	res := r.Exec(ctx, rep, cmd, nil)
+	cmd, err := r.command("UpdateClients", func() ([]string, error) { return r.c.UpdateClients(pathName, r.HomeDir()) })
+	if err != nil {
+		return err
+	}
+	res := r.execCommand(ctx, rep, "UpdateClients", cmd)
 	return res.Err
 }
 
@@ -356,20 +636,98 @@ func (r *DockerRelayer) StartRelayer(ctx context.Context, rep ibc.RelayerExecRep
 
 	containerImage := r.ContainerImage()
 	joinedPaths := strings.Join(pathNames, ".")
-	containerName := fmt.Sprintf("%s-%s-%s", r.c.Name(), joinedPaths, dockerutil.RandLowerCaseLetterString(5))
+	// Include the version in the name so that relayers of the same kind but different versions
+	// can be told apart when running side by side.
+	containerName := relayerContainerName(r.c.Name(), joinedPaths, r.testName, containerImage.Version)
 
-	cmd := r.c.StartRelayer(r.HomeDir(), pathNames...)
+	cmd, err := r.command("StartRelayer", func() ([]string, error) { return r.c.StartRelayer(r.HomeDir(), pathNames...) })
+	if err != nil {
+		return err
+	}
 
 	r.containerLifecycle = dockerutil.NewContainerLifecycle(r.log, r.client, containerName)
 
 	if err := r.containerLifecycle.CreateContainer(
 		ctx, r.testName, r.networkID, containerImage, nil,
-		r.Bind(), r.HostName(joinedPaths), cmd, nil,
+		r.Bind(), r.HostName(joinedPaths), cmd, r.containerEnv(nil),
+		r.containerOpts()...,
 	); err != nil {
 		return err
 	}
 
-	return r.containerLifecycle.StartContainer(ctx)
+	if err := r.containerLifecycle.StartContainer(ctx); err != nil {
+		return err
+	}
+
+	if r.startupCheck > 0 {
+		if err := r.watchStartup(ctx, rep, r.startupCheck); err != nil {
+			return err
+		}
+	}
+
+	if r.startOutput == OutputStream {
+		// Streaming ends when the container stops, so it must outlive the context used to start it.
+		containerID := r.containerLifecycle.ContainerID()
+		go func() {
+			w := r.logWriter(zap.String("container", containerName))
+			defer func() { _ = w.Close() }()
+			if err := dockerutil.StreamContainerLogs(context.Background(), r.client, containerID, w); err != nil {
+				r.log.Info("Failed to stream relayer logs", zap.Error(err))
+			}
+		}()
+	}
+	return nil
+}
+
+// ErrStartupFailed is returned when the relayer process exits, or is restarted repeatedly, shortly after being started.
+var ErrStartupFailed = errors.New("relayer failed on startup")
+
+// crashLoopRestarts is the number of restarts, through the RestartOnFailure option,
+// after which a relayer that keeps exiting on startup is considered to be crash looping.
+const crashLoopRestarts = 2
+
+// watchStartup fails fast if the relayer started through StartRelayer does not stay up for the given window,
+// returning its logs. A relayer that failed is stopped, so that it can be started again.
+func (r *DockerRelayer) watchStartup(ctx context.Context, rep ibc.RelayerExecReporter, window time.Duration) error {
+	err := r.containerLifecycle.WatchStartup(ctx, window, crashLoopRestarts)
+	if err == nil || !errors.Is(err, dockerutil.ErrCrashLoop) {
+		return err
+	}
+
+	logs, logsErr := r.Logs(ctx)
+	if logsErr != nil {
+		logs = logsErr.Error()
+	}
+	if err := r.StopRelayer(ctx, rep); err != nil {
+		r.log.Info("Failed to stop relayer after failed startup", zap.Error(err))
+	}
+	return fmt.Errorf("%w: %v; logs:\n%s", ErrStartupFailed, err, logs)
+}
+
+// logWriter returns a writer logging each line written to it through the relayer's logger.
+func (r *DockerRelayer) logWriter(fields ...zap.Field) *zapio.Writer {
+	return &zapio.Writer{Log: r.log.With(fields...), Level: zap.InfoLevel}
+}
+
+// containerEnv returns the user supplied environment variables followed by env,
+// so that a variable in env overrides one of the same name supplied through the Env option.
+func (r *DockerRelayer) containerEnv(env []string) []string {
+	if len(r.env) == 0 {
+		return env
+	}
+	return append(append([]string(nil), r.env...), env...)
+}
+
+// containerOpts returns the user supplied configuration for the relayer container.
+func (r *DockerRelayer) containerOpts() []dockerutil.ContainerOpt {
+	return []dockerutil.ContainerOpt{
+		dockerutil.WithLabels(r.labels),
+		dockerutil.WithExtraHosts(r.extraHosts),
+		dockerutil.WithDNS(r.dns),
+		dockerutil.WithResources(r.resources),
+		dockerutil.WithRestartPolicy(r.restartPolicy),
+		dockerutil.WithLogConfig(r.logConfig),
+	}
 }
 
 func (r *DockerRelayer) StopRelayer(ctx context.Context, rep ibc.RelayerExecReporter) error {
@@ -402,6 +760,9 @@ func (r *DockerRelayer) StopRelayer(ctx context.Context, rep ibc.RelayerExecRepo
 
 	stdout := stdoutBuf.String()
 	stderr := stderrBuf.String()
+	if r.startOutput == OutputDiscard {
+		stdout, stderr = "", ""
+	}
 
 	c, err := r.client.ContainerInspect(ctx, containerID)
 	if err != nil {
@@ -445,18 +806,77 @@ func (r *DockerRelayer) StopRelayer(ctx context.Context, rep ibc.RelayerExecRepo
 	return nil
 }
 
+// Logs returns the combined stdout and stderr output of the relayer process started through StartRelayer.
+func (r *DockerRelayer) Logs(ctx context.Context) (string, error) {
+	if r.containerLifecycle == nil {
+		return "", fmt.Errorf("relayer not started")
+	}
+	rc, err := r.client.ContainerLogs(ctx, r.containerLifecycle.ContainerID(), types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+	})
+	if err != nil {
+		return "", fmt.Errorf("retrieving container logs: %w", err)
+	}
+	defer func() { _ = rc.Close() }()
+
+	// Logs are multiplexed into one stream; see docs for ContainerLogs.
+	buf := new(bytes.Buffer)
+	if _, err := stdcopy.StdCopy(buf, buf, rc); err != nil {
+		return "", fmt.Errorf("demuxing container logs: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// IsRunning reports whether the relayer process started through StartRelayer is currently running.
+// It returns false if the relayer was never started, has been stopped or paused, or its container no longer exists.
+func (r *DockerRelayer) IsRunning(ctx context.Context) (bool, error) {
+	if r.containerLifecycle == nil {
+		return false, nil
+	}
+	return r.containerLifecycle.IsRunning(ctx)
+}
+
+// PauseRelayer freezes the relayer process started through StartRelayer without removing its container,
+// so no packets are relayed until ResumeRelayer is called.
+// An error is returned if the relayer is not currently running.
 func (r *DockerRelayer) PauseRelayer(ctx context.Context) error {
 	if r.containerLifecycle == nil {
 		return fmt.Errorf("container not running")
 	}
-	return r.client.ContainerPause(ctx, r.containerLifecycle.ContainerID())
+	if err := r.containerLifecycle.Running(ctx); err != nil {
+		return fmt.Errorf("PauseRelayer: %w", err)
+	}
+	return r.containerLifecycle.PauseContainer(ctx)
 }
 
+// ResumeRelayer resumes a relayer that was paused through PauseRelayer.
+// An error is returned if the relayer is not paused.
 func (r *DockerRelayer) ResumeRelayer(ctx context.Context) error {
 	if r.containerLifecycle == nil {
 		return fmt.Errorf("container not running")
 	}
-	return r.client.ContainerUnpause(ctx, r.containerLifecycle.ContainerID())
+	if err := r.containerLifecycle.Paused(ctx); err != nil {
+		return fmt.Errorf("ResumeRelayer: %w", err)
+	}
+	return r.containerLifecycle.UnpauseContainer(ctx)
+}
+
+// RestartRelayer stops the relayer process started through StartRelayer and starts it again for the given paths.
+// The home directory volume is kept, so the relayer resumes with the same config and keys.
+// An error is returned if the relayer was not running, or fails to start again, including the startup check
+// configured through StartupCheck.
+func (r *DockerRelayer) RestartRelayer(ctx context.Context, rep ibc.RelayerExecReporter, pathNames ...string) error {
+	if r.containerLifecycle == nil {
+		return fmt.Errorf("container not running")
+	}
+	if err := r.StopRelayer(ctx, rep); err != nil {
+		return fmt.Errorf("RestartRelayer: stopping relayer: %w", err)
+	}
+	if err := r.StartRelayer(ctx, rep, pathNames...); err != nil {
+		return fmt.Errorf("RestartRelayer: starting relayer: %w", err)
+	}
+	return nil
 }
 
 func (r *DockerRelayer) ContainerImage() ibc.DockerImage {
@@ -470,12 +890,25 @@ func (r *DockerRelayer) ContainerImage() ibc.DockerImage {
 	}
 }
 
-func (r *DockerRelayer) pullContainerImageIfNecessary(containerImage ibc.DockerImage) error {
-	if !r.pullImage {
-		return nil
+// ErrImageNotPresent is returned when the relayer image is not present locally and the pull policy forbids pulling it.
+var ErrImageNotPresent = errors.New("image not present locally")
+
+// pullContainerImageIfNecessary pulls the relayer image according to the configured pull policy.
+func (r *DockerRelayer) pullContainerImageIfNecessary(ctx context.Context, containerImage ibc.DockerImage) error {
+	ref := containerImage.Ref()
+	if r.pullPolicy != PullAlways {
+		_, _, err := r.client.ImageInspectWithRaw(ctx, ref)
+		switch {
+		case err == nil:
+			return nil
+		case !errdefs.IsNotFound(err):
+			return fmt.Errorf("inspecting image %s: %w", ref, err)
+		case r.pullPolicy == PullNever:
+			return fmt.Errorf("%s with pull policy Never: %w", ref, ErrImageNotPresent)
+		}
 	}
 
-	rc, err := r.client.ImagePull(context.TODO(), containerImage.Ref(), types.ImagePullOptions{})
+	rc, err := r.client.ImagePull(ctx, ref, types.ImagePullOptions{})
 	if err != nil {
 		return err
 	}
@@ -485,13 +918,62 @@ func (r *DockerRelayer) pullContainerImageIfNecessary(containerImage ibc.DockerI
 	return nil
 }
 
+// DockerClient returns the docker client used by the relayer, for operations that the relayer does not wrap,
+// e.g. collecting container stats. It is nil for relayers created through NewDockerRelayerWithExecutor.
+func (r *DockerRelayer) DockerClient() *client.Client {
+	return r.client
+}
+
+// NetworkID returns the ID of the docker network the relayer containers are attached to.
+func (r *DockerRelayer) NetworkID() string {
+	return r.networkID
+}
+
 func (r *DockerRelayer) Name() string {
 	return r.c.Name() + "-" + dockerutil.SanitizeContainerName(r.testName)
 }
 
 // Bind returns the home folder bind point for running the node.
 func (r *DockerRelayer) Bind() []string {
-	return []string{r.volumeName + ":" + r.HomeDir()}
+	binds := []string{r.volumeName + ":" + r.HomeDir()}
+	for _, m := range r.hostMounts {
+		binds = append(binds, m.bind())
+	}
+	return binds
+}
+
+// hostMount is a host path mounted into the relayer containers, see HostMount.
+type hostMount struct {
+	hostPath, containerPath string
+	readOnly                bool
+}
+
+// bind returns the docker bind specification of the mount.
+func (m hostMount) bind() string {
+	bind := m.hostPath + ":" + m.containerPath
+	if m.readOnly {
+		bind += ":ro"
+	}
+	return bind
+}
+
+// resolveHostMounts makes the host paths of the mounts absolute, as docker requires,
+// and verifies that they exist.
+func (r *DockerRelayer) resolveHostMounts() error {
+	for i, m := range r.hostMounts {
+		if !path.IsAbs(m.containerPath) {
+			return fmt.Errorf("host mount %s: container path %q is not absolute", m.hostPath, m.containerPath)
+		}
+		abs, err := filepath.Abs(m.hostPath)
+		if err != nil {
+			return fmt.Errorf("host mount %s: %w", m.hostPath, err)
+		}
+		if _, err := os.Stat(abs); err != nil {
+			return fmt.Errorf("host mount %s: %w", m.hostPath, err)
+		}
+		r.hostMounts[i].hostPath = abs
+	}
+	return nil
 }
 
 // HomeDir returns the home directory of the relayer on the underlying Docker container's filesystem.
@@ -499,6 +981,17 @@ func (r *DockerRelayer) HomeDir() string {
 	return r.homeDir
 }
 
+// relayerContainerName returns the container name of a relayer servicing the given paths. The name keeps the
+// "<relayer>-<paths>" prefix and appends the test name and relayer version, so the container is easy to find
+// with "docker ps". A random suffix keeps the name unique across runs.
+func relayerContainerName(relayerName, joinedPaths, testName, version string) string {
+	name := fmt.Sprintf("%s-%s-%s", relayerName, joinedPaths, testName)
+	if version != "" {
+		name += "-" + version
+	}
+	return dockerutil.SanitizeContainerName(name + "-" + dockerutil.RandLowerCaseLetterString(5))
+}
+
 func (r *DockerRelayer) HostName(pathName string) string {
 	return dockerutil.CondenseHostName(fmt.Sprintf("%s-%s", r.c.Name(), pathName))
 }
@@ -548,22 +1041,23 @@ type RelayerCommander interface {
 	// If the returned command is nil or empty, nothing will be executed.
 	Init(homeDir string) []string
 
-	// The remaining methods produce the command to run inside the container.
-
-	AddChainConfiguration(containerFilePath, homeDir string) []string
-	AddKey(chainID, keyName, coinType, homeDir string) []string
-	CreateChannel(pathName string, opts ibc.CreateChannelOptions, homeDir string) []string
-	CreateClients(pathName string, opts ibc.CreateClientOptions, homeDir string) []string
-	CreateConnections(pathName, homeDir string) []string
-	Flush(pathName, channelID, homeDir string) []string
-	GeneratePath(srcChainID, dstChainID, pathName, homeDir string) []string
-	UpdatePath(pathName, homeDir string, filter ibc.ChannelFilter) []string
-	GetChannels(chainID, homeDir string) []string
-	GetConnections(chainID, homeDir string) []string
-	GetClients(chainID, homeDir string) []string
-	LinkPath(pathName, homeDir string, channelOpts ibc.CreateChannelOptions, clientOpts ibc.CreateClientOptions) []string
-	RestoreKey(chainID, keyName, coinType, mnemonic, homeDir string) []string
-	StartRelayer(homeDir string, pathNames ...string) []string
-	UpdateClients(pathName, homeDir string) []string
+	// The remaining methods produce the command to run inside the container,
+	// or an error if the commander cannot produce it, e.g. ErrUnsupportedCapability.
+
+	AddChainConfiguration(containerFilePath, homeDir string) ([]string, error)
+	AddKey(chainID, keyName, coinType, homeDir string) ([]string, error)
+	CreateChannel(pathName string, opts ibc.CreateChannelOptions, homeDir string) ([]string, error)
+	CreateClients(pathName string, opts ibc.CreateClientOptions, homeDir string) ([]string, error)
+	CreateConnections(pathName, homeDir string) ([]string, error)
+	Flush(pathName, channelID, homeDir string) ([]string, error)
+	GeneratePath(srcChainID, dstChainID, pathName, homeDir string) ([]string, error)
+	UpdatePath(pathName, homeDir string, filter ibc.ChannelFilter) ([]string, error)
+	GetChannels(chainID, homeDir string) ([]string, error)
+	GetConnections(chainID, homeDir string) ([]string, error)
+	GetClients(chainID, homeDir string) ([]string, error)
+	LinkPath(pathName, homeDir string, channelOpts ibc.CreateChannelOptions, clientOpts ibc.CreateClientOptions) ([]string, error)
+	RestoreKey(chainID, keyName, coinType, mnemonic, homeDir string) ([]string, error)
+	StartRelayer(homeDir string, pathNames ...string) ([]string, error)
+	UpdateClients(pathName, homeDir string) ([]string, error)
 	CreateWallet(keyName, address, mnemonic string) ibc.Wallet
 }
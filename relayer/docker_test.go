@@ -0,0 +1,425 @@
+package relayer
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/internal/dockerutil"
+	"github.com/strangelove-ventures/interchaintest/v8/internal/mocktesting"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRelayerContainerName(t *testing.T) {
+	name := relayerContainerName("hermes", "gaia-osmo", "TestRelayer/sub test", "")
+	require.Regexp(t, regexp.MustCompile(`^hermes-gaia-osmo-TestRelayer_sub_test-[a-z]{5}$`), name)
+
+	name = relayerContainerName("rly", "", "TestRelayer", "")
+	require.Regexp(t, regexp.MustCompile(`^rly--TestRelayer-[a-z]{5}$`), name)
+
+	require.NotEqual(t, relayerContainerName("rly", "", "TestRelayer", ""), relayerContainerName("rly", "", "TestRelayer", ""))
+}
+
+func TestRelayerContainerName_Versions(t *testing.T) {
+	a := relayerContainerName("hermes", "p", "TestVersions", "1.4.0")
+	b := relayerContainerName("hermes", "p", "TestVersions", "1.6.0")
+	require.Regexp(t, regexp.MustCompile(`^hermes-p-TestVersions-1.4.0-[a-z]{5}$`), a)
+	require.Regexp(t, regexp.MustCompile(`^hermes-p-TestVersions-1.6.0-[a-z]{5}$`), b)
+}
+
+func TestPullPolicyNever(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	cli, _ := dockerutil.DockerSetup(t)
+	ctx := context.Background()
+
+	present := ibc.DockerImage{Repository: "busybox", Version: "stable"}
+	rc, err := cli.ImagePull(ctx, present.Ref(), types.ImagePullOptions{})
+	require.NoError(t, err)
+	_, _ = io.Copy(io.Discard, rc)
+	_ = rc.Close()
+
+	r := &DockerRelayer{client: cli}
+	ImagePullPolicy(PullNever)(r)
+
+	require.NoError(t, r.pullContainerImageIfNecessary(ctx, present))
+
+	absent := ibc.DockerImage{Repository: "interchaintest/does-not-exist", Version: "never"}
+	err = r.pullContainerImageIfNecessary(ctx, absent)
+	require.ErrorIs(t, err, ErrImageNotPresent)
+	require.ErrorContains(t, err, absent.Ref())
+}
+
+func TestDockerClient(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	cli, network := dockerutil.DockerSetup(t)
+	r := &DockerRelayer{client: cli, networkID: network}
+	require.Same(t, cli, r.DockerClient())
+	require.Equal(t, network, r.NetworkID())
+
+	_, err := r.DockerClient().ContainerList(context.Background(), types.ContainerListOptions{All: true})
+	require.NoError(t, err)
+
+	require.Nil(t, NewDockerRelayerWithExecutor(zap.NewNop(), t.Name(), fakeCommander{}, nil).DockerClient())
+}
+
+func TestContainerOptsNameResolution(t *testing.T) {
+	r := &DockerRelayer{}
+	ExtraHosts("validator.example.com:10.0.0.5")(r)
+	DNS("8.8.8.8")(r)
+
+	cfg, hostCfg := &container.Config{}, &container.HostConfig{DNS: []string{}}
+	for _, opt := range r.containerOpts() {
+		opt(cfg, hostCfg)
+	}
+	require.Equal(t, []string{"validator.example.com:10.0.0.5"}, hostCfg.ExtraHosts)
+	require.Equal(t, []string{"8.8.8.8"}, hostCfg.DNS)
+
+	// Without options, docker's defaults are kept.
+	cfg, hostCfg = &container.Config{}, &container.HostConfig{DNS: []string{}}
+	for _, opt := range (&DockerRelayer{}).containerOpts() {
+		opt(cfg, hostCfg)
+	}
+	require.Empty(t, hostCfg.ExtraHosts)
+	require.Empty(t, hostCfg.DNS)
+}
+
+func TestContainerOptsResources(t *testing.T) {
+	r := &DockerRelayer{}
+	MemoryLimit(512 << 20)(r)
+	CPULimit(1.5)(r)
+
+	cfg, hostCfg := &container.Config{}, &container.HostConfig{}
+	for _, opt := range r.containerOpts() {
+		opt(cfg, hostCfg)
+	}
+	require.Equal(t, int64(512<<20), hostCfg.Memory)
+	require.Equal(t, int64(1_500_000_000), hostCfg.NanoCPUs)
+
+	// Unlimited by default.
+	cfg, hostCfg = &container.Config{}, &container.HostConfig{}
+	for _, opt := range (&DockerRelayer{}).containerOpts() {
+		opt(cfg, hostCfg)
+	}
+	require.Zero(t, hostCfg.Memory)
+	require.Zero(t, hostCfg.NanoCPUs)
+}
+
+func TestOutputStreamLogWriter(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	r := &DockerRelayer{log: zap.New(core)}
+	StartOutput(OutputStream)(r)
+	require.Equal(t, OutputCapture, r.execOutput)
+	require.Equal(t, OutputStream, r.startOutput)
+
+	w := r.logWriter(zap.String("container", "relayer"))
+	_, err := w.Write([]byte("starting relayer\nrelaying packets\npartial"))
+	require.NoError(t, err)
+	require.Equal(t, 2, logs.Len())
+	require.NoError(t, w.Close())
+
+	entries := logs.All()
+	require.Len(t, entries, 3)
+	require.Equal(t, "starting relayer", entries[0].Message)
+	require.Equal(t, "partial", entries[2].Message)
+	require.Equal(t, "relayer", entries[0].ContextMap()["container"])
+}
+
+// fakeCommander generates recognizable create-client commands.
+// Calling any other RelayerCommander method panics.
+type fakeCommander struct {
+	RelayerCommander
+}
+
+func (fakeCommander) Name() string {
+	return "fake"
+}
+
+func (fakeCommander) CreateClients(pathName string, opts ibc.CreateClientOptions, homeDir string) ([]string, error) {
+	return []string{"fake", "--home", homeDir, "create", "clients", pathName, "--trusting-period", opts.TrustingPeriod}, nil
+}
+
+func TestNewDockerRelayerWithExecutor(t *testing.T) {
+	ctx := context.Background()
+
+	var cmds [][]string
+	exec := func(_ context.Context, cmd []string, _ []string) ibc.RelayerExecResult {
+		cmds = append(cmds, cmd)
+		if cmd[len(cmd)-1] == "0" {
+			return ibc.RelayerExecResult{Err: errors.New("exit code 1: invalid trusting period"), ExitCode: 1}
+		}
+		return ibc.RelayerExecResult{Stdout: []byte("created clients")}
+	}
+	r := NewDockerRelayerWithExecutor(zap.NewNop(), t.Name(), fakeCommander{}, exec, HomeDir("/home/fake"))
+
+	require.NoError(t, r.CreateClients(ctx, ibc.NopRelayerExecReporter{}, "p", ibc.CreateClientOptions{TrustingPeriod: "336h"}))
+	require.Equal(t, [][]string{{"fake", "--home", "/home/fake", "create", "clients", "p", "--trusting-period", "336h"}}, cmds)
+
+	err := r.CreateClients(ctx, ibc.NopRelayerExecReporter{}, "p", ibc.DefaultClientOpts())
+	require.ErrorContains(t, err, "invalid trusting period")
+	require.Len(t, cmds, 2)
+}
+
+func TestCheckConnectivity(t *testing.T) {
+	ctx := context.Background()
+
+	var probed []string
+	exec := func(_ context.Context, cmd []string, _ []string) ibc.RelayerExecResult {
+		host, port := cmd[len(cmd)-2], cmd[len(cmd)-1]
+		probed = append(probed, host+":"+port)
+		if host == "gaia-val-0" {
+			return ibc.RelayerExecResult{}
+		}
+		return ibc.RelayerExecResult{Err: errors.New("exit code 1"), ExitCode: 1, Stderr: []byte("connection refused\n")}
+	}
+	r := NewDockerRelayerWithExecutor(zap.NewNop(), t.Name(), fakeCommander{}, exec)
+
+	require.NoError(t, r.CheckConnectivity(ctx, "http://gaia-val-0:26657", "gaia-val-0:9090"))
+	require.Equal(t, []string{"gaia-val-0:26657", "gaia-val-0:9090"}, probed)
+
+	err := r.CheckConnectivity(ctx, "http://gaia-val-0:26657", "osmosis-val-0:9090")
+	require.EqualError(t, err, "grpc endpoint osmosis-val-0:9090 unreachable from relayer container: exit code 1: connection refused")
+
+	err = r.CheckConnectivity(ctx, "https://osmosis-val-0", "")
+	require.EqualError(t, err, "rpc endpoint https://osmosis-val-0 unreachable from relayer container: exit code 1: connection refused")
+	require.Equal(t, "osmosis-val-0:443", probed[len(probed)-1])
+
+	err = r.CheckConnectivity(ctx, "gaia-val-0", "")
+	require.ErrorContains(t, err, `invalid address "gaia-val-0"`)
+}
+
+func TestEnv(t *testing.T) {
+	ctx := context.Background()
+
+	var envs [][]string
+	exec := func(_ context.Context, _ []string, env []string) ibc.RelayerExecResult {
+		envs = append(envs, env)
+		return ibc.RelayerExecResult{}
+	}
+	r := NewDockerRelayerWithExecutor(zap.NewNop(), t.Name(), fakeCommander{}, exec, Env("RUST_LOG=debug", "RUST_BACKTRACE=1"))
+
+	r.Exec(ctx, ibc.NopRelayerExecReporter{}, []string{"hermes", "version"}, nil)
+	r.Exec(ctx, ibc.NopRelayerExecReporter{}, []string{"hermes", "version"}, []string{"RUST_LOG=trace"})
+	require.Equal(t, [][]string{
+		{"RUST_LOG=debug", "RUST_BACKTRACE=1"},
+		{"RUST_LOG=debug", "RUST_BACKTRACE=1", "RUST_LOG=trace"},
+	}, envs)
+
+	// No variables are set by default.
+	require.Nil(t, (&DockerRelayer{}).containerEnv(nil))
+}
+
+// crashingCommander starts a relayer process that fails immediately, the way a relayer with an invalid config does.
+// Calling any other RelayerCommander method panics.
+type crashingCommander struct {
+	RelayerCommander
+}
+
+func (crashingCommander) Name() string {
+	return "crashing"
+}
+
+func (crashingCommander) Init(string) []string {
+	return nil
+}
+
+func (crashingCommander) StartRelayer(string, ...string) ([]string, error) {
+	return []string{"sh", "-c", "echo invalid config >&2; exit 1"}, nil
+}
+
+func TestStartupCrashLoop(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	cli, network := dockerutil.DockerSetup(t)
+	ctx := context.Background()
+
+	const window = time.Minute
+	r, err := NewDockerRelayer(ctx, zap.NewNop(), t.Name(), cli, network, crashingCommander{},
+		CustomDockerImage("busybox", "stable", ""),
+		RestartOnFailure(100),
+		StartupCheck(window),
+	)
+	require.NoError(t, err)
+
+	start := time.Now()
+	err = r.StartRelayer(ctx, ibc.NopRelayerExecReporter{}, "p")
+	require.ErrorIs(t, err, ErrStartupFailed)
+	require.ErrorContains(t, err, "invalid config")
+	require.Less(t, time.Since(start), window)
+
+	// The crashed relayer was cleaned up.
+	running, err := r.IsRunning(ctx)
+	require.NoError(t, err)
+	require.False(t, running)
+}
+
+// sleepingCommander starts a relayer process that runs until it is stopped.
+// Calling any other RelayerCommander method panics.
+type sleepingCommander struct {
+	RelayerCommander
+}
+
+func (sleepingCommander) Name() string {
+	return "sleeping"
+}
+
+func (sleepingCommander) Init(string) []string {
+	return nil
+}
+
+func (sleepingCommander) StartRelayer(string, ...string) ([]string, error) {
+	return []string{"sleep", "600"}, nil
+}
+
+func TestHostMount(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewDockerRelayer(ctx, zap.NewNop(), t.Name(), nil, "", sleepingCommander{},
+		HostMount(filepath.Join(t.TempDir(), "missing"), "/certs", true))
+	require.ErrorContains(t, err, "no such file or directory")
+
+	_, err = NewDockerRelayer(ctx, zap.NewNop(), t.Name(), nil, "", sleepingCommander{},
+		HostMount(t.TempDir(), "certs", true))
+	require.ErrorContains(t, err, `container path "certs" is not absolute`)
+
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	cli, network := dockerutil.DockerSetup(t)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ca.pem"), []byte("certificate"), 0o644))
+
+	r, err := NewDockerRelayer(ctx, zap.NewNop(), t.Name(), cli, network, sleepingCommander{},
+		CustomDockerImage("busybox", "stable", ""),
+		HostMount(dir, "/certs", true),
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, r.StartRelayer(ctx, ibc.NopRelayerExecReporter{}, "p"))
+	t.Cleanup(func() {
+		_ = r.StopRelayer(ctx, ibc.NopRelayerExecReporter{})
+	})
+
+	info, err := cli.ContainerInspect(ctx, r.containerLifecycle.ContainerID())
+	require.NoError(t, err)
+	require.Contains(t, info.HostConfig.Binds, dir+":/certs:ro")
+
+	res := r.Exec(ctx, ibc.NopRelayerExecReporter{}, []string{"cat", "/certs/ca.pem"}, nil)
+	require.NoError(t, res.Err)
+	require.Equal(t, "certificate", string(res.Stdout))
+}
+
+func TestCommandTimeout(t *testing.T) {
+	ctx := context.Background()
+
+	var deadline time.Time
+	exec := func(ctx context.Context, _ []string, _ []string) ibc.RelayerExecResult {
+		deadline, _ = ctx.Deadline()
+		select {
+		case <-ctx.Done():
+			return ibc.RelayerExecResult{Err: ctx.Err()}
+		case <-time.After(10 * time.Second):
+			return ibc.RelayerExecResult{}
+		}
+	}
+
+	r := NewDockerRelayerWithExecutor(zap.NewNop(), t.Name(), fakeCommander{}, exec, CommandTimeout("CreateClients", 50*time.Millisecond))
+	start := time.Now()
+	err := r.CreateClients(ctx, ibc.NopRelayerExecReporter{}, "p", ibc.DefaultClientOpts())
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.ErrorContains(t, err, "CreateClients: timed out after 50ms")
+	require.Less(t, time.Since(start), 5*time.Second)
+
+	// Without the option, handshakes get the longer default timeout.
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	r = NewDockerRelayerWithExecutor(zap.NewNop(), t.Name(), fakeCommander{}, exec)
+	err = r.CreateClients(ctx, ibc.NopRelayerExecReporter{}, "p", ibc.DefaultClientOpts())
+	require.ErrorIs(t, err, context.Canceled)
+	require.NotContains(t, err.Error(), "timed out")
+	require.WithinDuration(t, time.Now().Add(handshakeCommandTimeout), deadline, time.Minute)
+	require.Equal(t, queryCommandTimeout, r.commandTimeout("GetChannels"))
+}
+
+func TestContainerOptsLogDriver(t *testing.T) {
+	r := &DockerRelayer{}
+	LogDriver("json-file", map[string]string{"max-size": "100m", "max-file": "5"})(r)
+
+	cfg, hostCfg := &container.Config{}, &container.HostConfig{}
+	for _, opt := range r.containerOpts() {
+		opt(cfg, hostCfg)
+	}
+	require.Equal(t, container.LogConfig{
+		Type:   "json-file",
+		Config: map[string]string{"max-size": "100m", "max-file": "5"},
+	}, hostCfg.LogConfig)
+
+	// The daemon's default driver is used by default.
+	cfg, hostCfg = &container.Config{}, &container.HostConfig{}
+	for _, opt := range (&DockerRelayer{}).containerOpts() {
+		opt(cfg, hostCfg)
+	}
+	require.Empty(t, hostCfg.LogConfig.Type)
+}
+
+func TestPreserveHomeDir(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	mt := mocktesting.NewT(t.Name())
+
+	var r *DockerRelayer
+	mt.Simulate(func() {
+		cli, network := dockerutil.DockerSetup(mt)
+
+		var err error
+		r, err = NewDockerRelayer(ctx, zap.NewNop(), mt.Name(), cli, network, sleepingCommander{},
+			CustomDockerImage("busybox", "stable", ""),
+			PreserveHomeDir(dir),
+		)
+		require.NoError(t, err)
+		require.NoError(t, r.WriteFileToHomeDir(ctx, "config/config.toml", []byte("[global]\n")))
+
+		// Nothing is copied before cleanup.
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		require.Empty(t, entries)
+	})
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Contains(t, entries[0].Name(), r.Name())
+
+	bz, err := os.ReadFile(filepath.Join(dir, entries[0].Name(), "config", "config.toml"))
+	require.NoError(t, err)
+	require.Equal(t, "[global]\n", string(bz))
+}
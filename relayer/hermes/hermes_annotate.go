@@ -0,0 +1,145 @@
+package hermes
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml"
+)
+
+// The sources of the settings reported by AnnotatedConfig.
+const (
+	sourceDefault     = "default"
+	sourceChainConfig = "ChainConfig"
+	sourceOverride    = "override"
+)
+
+// chainConfigKeys are the keys of a chain entry whose values are derived from the ibc.ChainConfig and addresses
+// passed to AddChainConfiguration.
+var chainConfigKeys = map[string]bool{
+	"id":               true,
+	"rpc_addr":         true,
+	"grpc_addr":        true,
+	"event_source.url": true,
+	"account_prefix":   true,
+	"key_name":         true,
+	"gas_multiplier":   true,
+	"gas_price.price":  true,
+	"gas_price.denom":  true,
+}
+
+// AnnotatedConfig returns the generated hermes config with a comment on every setting naming its source:
+// "default" for the defaults of NewConfig, "ChainConfig" for values taken from the chain configurations passed
+// to AddChainConfiguration, and "override" for values changed through the setters of the relayer.
+//
+// This is meant for debugging and is not the file hermes reads; it is also written by DumpDiagnostics.
+// An error is returned if the config is supplied through SetConfigOverride.
+func (r *Relayer) AnnotatedConfig() ([]byte, error) {
+	if r.configOverride != nil {
+		return nil, errors.New("the hermes config is supplied through SetConfigOverride and cannot be annotated")
+	}
+
+	defaultsBz, err := toml.Marshal(NewConfig(r.chainConfigs...))
+	if err != nil {
+		return nil, err
+	}
+	defaults, err := flattenConfig(defaultsBz)
+	if err != nil {
+		return nil, err
+	}
+	bz, err := toml.Marshal(r.generateConfig())
+	if err != nil {
+		return nil, err
+	}
+	generated, err := flattenConfig(bz)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		out     bytes.Buffer
+		table   string
+		chainIx = -1
+	)
+	scanner := bufio.NewScanner(bytes.NewReader(bz))
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "[["):
+			table = strings.Trim(trimmed, "[]")
+			if table == "chains" {
+				chainIx++
+				table = "chains." + strconv.Itoa(chainIx)
+			}
+		case strings.HasPrefix(trimmed, "["):
+			table = strings.Trim(trimmed, "[]")
+			if rest, ok := strings.CutPrefix(table, "chains."); ok {
+				table = "chains." + strconv.Itoa(chainIx) + "." + rest
+			}
+		case strings.Contains(trimmed, "="):
+			key := strings.TrimSpace(trimmed[:strings.Index(trimmed, "=")])
+			if table != "" {
+				key = table + "." + key
+			}
+			line = fmt.Sprintf("%s # %s", line, settingSource(key, defaults, generated))
+		}
+		out.WriteString(line)
+		out.WriteByte('\n')
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// settingSource returns the source of the setting with the given flattened key.
+func settingSource(key string, defaults, generated map[string]string) string {
+	if defaults[key] != generated[key] {
+		return sourceOverride
+	}
+	if rest, ok := strings.CutPrefix(key, "chains."); ok {
+		if _, chainKey, ok := strings.Cut(rest, "."); ok && chainConfigKeys[chainKey] {
+			return sourceChainConfig
+		}
+	}
+	return sourceDefault
+}
+
+// flattenConfig returns the settings of a TOML encoded hermes config keyed by their dotted path,
+// where chains are identified by their index, e.g. "chains.0.rpc_timeout".
+func flattenConfig(bz []byte) (map[string]string, error) {
+	tree, err := toml.LoadBytes(bz)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse hermes config: %w", err)
+	}
+
+	flat := map[string]string{}
+	var flatten func(prefix string, v any)
+	flatten = func(prefix string, v any) {
+		switch v := v.(type) {
+		case map[string]any:
+			for k, child := range v {
+				flatten(prefix+k+".", child)
+			}
+		case []any:
+			if len(v) > 0 {
+				if _, isTable := v[0].(map[string]any); isTable {
+					for i, child := range v {
+						flatten(prefix+strconv.Itoa(i)+".", child)
+					}
+					return
+				}
+			}
+			flat[strings.TrimSuffix(prefix, ".")] = fmt.Sprint(v)
+		default:
+			flat[strings.TrimSuffix(prefix, ".")] = fmt.Sprint(v)
+		}
+	}
+	flatten("", tree.ToMap())
+	return flat, nil
+}
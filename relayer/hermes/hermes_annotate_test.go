@@ -0,0 +1,49 @@
+package hermes
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pelletier/go-toml"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnnotatedConfig(t *testing.T) {
+	r := &Relayer{}
+	r.EnablePacketLogging()
+	r.SetRPCTimeout(time.Minute)
+	_, err := r.configContent(ibc.ChainConfig{ChainID: "gaia-1", Denom: "uatom", GasPrices: "0.01uatom", Bech32Prefix: "cosmos"}, "relayer", "http://rpc:26657", "grpc:9090")
+	require.NoError(t, err)
+
+	bz, err := r.AnnotatedConfig()
+	require.NoError(t, err)
+
+	sources := map[string]string{}
+	for _, line := range strings.Split(string(bz), "\n") {
+		setting, source, ok := strings.Cut(line, " # ")
+		if !ok {
+			continue
+		}
+		key, _, _ := strings.Cut(strings.TrimSpace(setting), " ")
+		sources[key] = source
+	}
+	require.Equal(t, "override", sources["log_level"])
+	require.Equal(t, "override", sources["rpc_timeout"])
+	require.Equal(t, "ChainConfig", sources["id"])
+	require.Equal(t, "ChainConfig", sources["account_prefix"])
+	require.Equal(t, "ChainConfig", sources["url"])
+	require.Equal(t, "default", sources["max_msg_num"])
+	require.Equal(t, "default", sources["clear_on_start"])
+
+	// The annotations are comments, so the annotated config decodes to the generated one.
+	var annotated, generated Config
+	require.NoError(t, toml.Unmarshal(bz, &annotated))
+	require.NoError(t, toml.Unmarshal(mustMarshal(t, r.generateConfig()), &generated))
+	require.Equal(t, generated, annotated)
+
+	r.SetConfigOverride([]byte("[global]"))
+	_, err = r.AnnotatedConfig()
+	require.ErrorContains(t, err, "SetConfigOverride")
+}
@@ -4,7 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
 
+	"github.com/hashicorp/go-version"
 	"github.com/strangelove-ventures/interchaintest/v8/ibc"
 	"github.com/strangelove-ventures/interchaintest/v8/relayer"
 	"go.uber.org/zap"
@@ -18,6 +23,70 @@ var _ relayer.RelayerCommander = &commander{}
 type commander struct {
 	log             *zap.Logger
 	extraStartFlags []string
+
+	// configPath is the location of the hermes config file relative to the relayer home directory.
+	// If empty, the location of the layout is used.
+	configPath string
+
+	// layout is the home directory layout of the hermes version in the relayer image.
+	layout homeLayout
+
+	// binary is the hermes executable used as the first argument of every command. If empty, hermes is used.
+	binary string
+
+	// logFormat is the format of the logs of "hermes start".
+	logFormat LogFormat
+}
+
+// relativeConfigPath returns the location of the hermes config file relative to the relayer home directory.
+func (c commander) relativeConfigPath() string {
+	switch {
+	case c.configPath != "":
+		return c.configPath
+	case c.layout.configPath != "":
+		return c.layout.configPath
+	default:
+		return hermesConfigPath
+	}
+}
+
+// homeLayout describes where a hermes version keeps its files within the relayer home directory.
+type homeLayout struct {
+	// configPath is the location of the config file relative to the home directory.
+	configPath string
+}
+
+var (
+	// dotHermesLayout keeps the config in the .hermes directory, where hermes looks for it by default.
+	dotHermesLayout = homeLayout{configPath: hermesConfigPath}
+	// flatLayout keeps the config at the root of the home directory, as expected by hermes releases
+	// before dotHermesMinVersion, which did not look for it in the .hermes directory.
+	flatLayout = homeLayout{configPath: "config.toml"}
+
+	dotHermesMinVersion = version.Must(version.NewVersion("0.7.0"))
+)
+
+// layoutForVersion returns the home directory layout of the given hermes version, e.g. "1.6.0" or "v0.6.2".
+// Versions that are not semantic versions, e.g. "latest" or a branch name, are assumed to be recent.
+func layoutForVersion(v string) homeLayout {
+	parsed, err := version.NewVersion(strings.TrimPrefix(v, "v"))
+	if err != nil || !parsed.LessThan(dotHermesMinVersion) {
+		return dotHermesLayout
+	}
+	return flatLayout
+}
+
+// executable returns the hermes executable used as the first argument of every command.
+func (c commander) executable() string {
+	if c.binary == "" {
+		return hermes
+	}
+	return c.binary
+}
+
+// hermesCmd returns the hermes command line for the given arguments, pointing hermes at the relayer's config file.
+func (c commander) hermesCmd(homeDir string, args ...string) []string {
+	return append([]string{c.executable(), "--config", path.Join(homeDir, c.relativeConfigPath())}, args...)
 }
 
 func (c commander) Name() string {
@@ -40,7 +109,8 @@ func (c commander) ParseGetChannelsOutput(stdout, stderr string) ([]ibc.ChannelO
 	jsonBz := extractJsonResult([]byte(stdout))
 	var result ChannelOutputResult
 	if err := json.Unmarshal(jsonBz, &result); err != nil {
-		return nil, err
+		c.log.Error("Failed to parse channels output", zap.Error(err))
+		return nil, parseError("channels", err)
 	}
 
 	var ibcChannelOutput []ibc.ChannelOutput
@@ -59,14 +129,15 @@ func (c commander) ParseGetChannelsOutput(stdout, stderr string) ([]ibc.ChannelO
 		})
 	}
 
-	return ibcChannelOutput, nil
+	return normalizeChannels(ibcChannelOutput), nil
 }
 
 func (c commander) ParseGetConnectionsOutput(stdout, stderr string) (ibc.ConnectionOutputs, error) {
 	jsonBz := extractJsonResult([]byte(stdout))
 	var queryResult ConnectionQueryResult
 	if err := json.Unmarshal(jsonBz, &queryResult); err != nil {
-		return ibc.ConnectionOutputs{}, err
+		c.log.Error("Failed to parse connections output", zap.Error(err))
+		return ibc.ConnectionOutputs{}, parseError("connections", err)
 	}
 
 	var outputs ibc.ConnectionOutputs
@@ -94,14 +165,15 @@ func (c commander) ParseGetConnectionsOutput(stdout, stderr string) (ibc.Connect
 			},
 		})
 	}
-	return outputs, nil
+	return normalizeConnections(outputs), nil
 }
 
 func (c commander) ParseGetClientsOutput(stdout, stderr string) (ibc.ClientOutputs, error) {
 	jsonBz := extractJsonResult([]byte(stdout))
 	var queryResult ClientQueryResult
 	if err := json.Unmarshal(jsonBz, &queryResult); err != nil {
-		return ibc.ClientOutputs{}, err
+		c.log.Error("Failed to parse clients output", zap.Error(err))
+		return ibc.ClientOutputs{}, parseError("clients", err)
 	}
 
 	var clientOutputs []*ibc.ClientOutput
@@ -121,76 +193,103 @@ func (c commander) Init(homeDir string) []string {
 	return nil
 }
 
-func (c commander) GetChannels(chainID, homeDir string) []string {
+func (c commander) GetChannels(chainID, homeDir string) ([]string, error) {
 	// the --verbose and --show-counterparty options are required to get enough information to correctly populate
 	// the path.
-	return []string{hermes, "--json", "query", "channels", "--chain", chainID, "--show-counterparty", "--verbose"}
+	return c.hermesCmd(homeDir, "--json", "query", "channels", "--chain", chainID, "--show-counterparty", "--verbose"), nil
+}
+
+func (c commander) GetConnections(chainID, homeDir string) ([]string, error) {
+	return c.hermesCmd(homeDir, "--json", "query", "connections", "--chain", chainID, "--verbose"), nil
+}
+
+func (c commander) GetClients(chainID, homeDir string) ([]string, error) {
+	return c.hermesCmd(homeDir, "--json", "query", "clients", "--host-chain", chainID), nil
+}
+
+// QueryChainStatus returns the command to query the latest height and timestamp of the given chain.
+func (c commander) QueryChainStatus(chainID, homeDir string) []string {
+	return c.hermesCmd(homeDir, "--json", "query", "chain", "status", "--chain", chainID)
 }
 
-func (c commander) GetConnections(chainID, homeDir string) []string {
-	return []string{hermes, "--config", fmt.Sprintf("%s/%s", homeDir, hermesConfigPath), "--json", "query", "connections", "--chain", chainID, "--verbose"}
+// ParseQueryChainStatusOutput extracts the latest height from the output of QueryChainStatus.
+func (c commander) ParseQueryChainStatusOutput(stdout, stderr string) (uint64, error) {
+	jsonBz := extractJsonResult([]byte(stdout))
+	var result ChainStatusResult
+	if err := json.Unmarshal(jsonBz, &result); err != nil {
+		c.log.Error("Failed to parse chain status output", zap.Error(err))
+		return 0, parseError("chain status", err)
+	}
+	return result.Result.Height.RevisionHeight, nil
 }
 
-func (c commander) GetClients(chainID, homeDir string) []string {
-	return []string{hermes, "--config", fmt.Sprintf("%s/%s", homeDir, hermesConfigPath), "--json", "query", "clients", "--host-chain", chainID}
+// UpgradeClient returns the command to upgrade the client on the host chain once the chain it tracks
+// has halted at the given upgrade height.
+func (c commander) UpgradeClient(chainID, clientID string, upgradeHeight int64, homeDir string) []string {
+	return c.hermesCmd(homeDir, "--json", "upgrade", "client", "--host-chain", chainID, "--client", clientID, "--upgrade-height", strconv.FormatInt(upgradeHeight, 10))
 }
 
-func (c commander) StartRelayer(homeDir string, pathNames ...string) []string {
-	cmd := []string{hermes, "--config", fmt.Sprintf("%s/%s", homeDir, hermesConfigPath), "start"}
+func (c commander) StartRelayer(homeDir string, pathNames ...string) ([]string, error) {
+	var cmd []string
+	if c.logFormat == LogFormatJSON {
+		cmd = c.hermesCmd(homeDir, "--json", "start")
+	} else {
+		cmd = c.hermesCmd(homeDir, "start")
+	}
 	cmd = append(cmd, c.extraStartFlags...)
-	return cmd
+	return cmd, nil
 }
 
 func (c commander) CreateWallet(keyName, address, mnemonic string) ibc.Wallet {
 	return NewWallet(keyName, address, mnemonic)
 }
 
-func (c commander) UpdatePath(pathName, homeDir string, filter ibc.ChannelFilter) []string {
+func (c commander) UpdatePath(pathName, homeDir string, filter ibc.ChannelFilter) ([]string, error) {
 	// TODO: figure out how to implement this.
-	panic("implement me")
+	return nil, fmt.Errorf("update path: %w", relayer.ErrUnsupportedCapability)
 }
 
 // the following methods do not have a single command that cleanly maps to a single hermes command without
 // additional logic wrapping them. They have been implemented one layer up in the hermes relayer.
 
-func (c commander) UpdateClients(pathName, homeDir string) []string {
-	panic("update clients implemented in hermes relayer not the commander")
+func (c commander) UpdateClients(pathName, homeDir string) ([]string, error) {
+	return nil, fmt.Errorf("update clients is implemented in the hermes relayer, not the commander: %w", relayer.ErrUnsupportedCapability)
 }
 
-func (c commander) GeneratePath(srcChainID, dstChainID, pathName, homeDir string) []string {
-	panic("generate path implemented in hermes relayer not the commander")
+func (c commander) GeneratePath(srcChainID, dstChainID, pathName, homeDir string) ([]string, error) {
+	return nil, fmt.Errorf("generate path is implemented in the hermes relayer, not the commander: %w", relayer.ErrUnsupportedCapability)
 }
 
-func (c commander) LinkPath(pathName, homeDir string, channelOpts ibc.CreateChannelOptions, clientOpts ibc.CreateClientOptions) []string {
-	panic("link path implemented in hermes relayer not the commander")
+func (c commander) LinkPath(pathName, homeDir string, channelOpts ibc.CreateChannelOptions, clientOpts ibc.CreateClientOptions) ([]string, error) {
+	return nil, fmt.Errorf("link path is implemented in the hermes relayer, not the commander: %w", relayer.ErrUnsupportedCapability)
 }
 
-func (c commander) RestoreKey(chainID, keyName, coinType, mnemonic, homeDir string) []string {
-	panic("restore key implemented in hermes relayer not the commander")
+func (c commander) RestoreKey(chainID, keyName, coinType, mnemonic, homeDir string) ([]string, error) {
+	return nil, fmt.Errorf("restore key is implemented in the hermes relayer, not the commander: %w", relayer.ErrUnsupportedCapability)
 }
 
-func (c commander) AddChainConfiguration(containerFilePath, homeDir string) []string {
-	panic("add chain configuration implemented in hermes relayer not the commander")
+func (c commander) AddChainConfiguration(containerFilePath, homeDir string) ([]string, error) {
+	return nil, fmt.Errorf("add chain configuration is implemented in the hermes relayer, not the commander: %w", relayer.ErrUnsupportedCapability)
 }
 
-func (c commander) AddKey(chainID, keyName, coinType, homeDir string) []string {
-	panic("add key implemented in hermes relayer not the commander")
+func (c commander) AddKey(chainID, keyName, coinType, homeDir string) ([]string, error) {
+	return nil, fmt.Errorf("add key is implemented in the hermes relayer, not the commander: %w", relayer.ErrUnsupportedCapability)
 }
 
-func (c commander) CreateChannel(pathName string, opts ibc.CreateChannelOptions, homeDir string) []string {
-	panic("create channel implemented in hermes relayer not the commander")
+func (c commander) CreateChannel(pathName string, opts ibc.CreateChannelOptions, homeDir string) ([]string, error) {
+	return nil, fmt.Errorf("create channel is implemented in the hermes relayer, not the commander: %w", relayer.ErrUnsupportedCapability)
 }
 
-func (c commander) CreateClients(pathName string, opts ibc.CreateClientOptions, homeDir string) []string {
-	panic("create clients implemented in hermes relayer not the commander")
+func (c commander) CreateClients(pathName string, opts ibc.CreateClientOptions, homeDir string) ([]string, error) {
+	return nil, fmt.Errorf("create clients is implemented in the hermes relayer, not the commander: %w", relayer.ErrUnsupportedCapability)
 }
 
-func (c commander) CreateConnections(pathName string, homeDir string) []string {
-	panic("create connections implemented in hermes relayer not the commander")
+func (c commander) CreateConnections(pathName string, homeDir string) ([]string, error) {
+	return nil, fmt.Errorf("create connections is implemented in the hermes relayer, not the commander: %w", relayer.ErrUnsupportedCapability)
 }
 
-func (c commander) Flush(pathName, channelID, homeDir string) []string {
-	panic("flush implemented in hermes relayer not the commander")
+func (c commander) Flush(pathName, channelID, homeDir string) ([]string, error) {
+	return nil, fmt.Errorf("flush is implemented in the hermes relayer, not the commander: %w", relayer.ErrUnsupportedCapability)
 }
 
 func (c commander) ConfigContent(ctx context.Context, cfg ibc.ChainConfig, keyName, rpcAddr, grpcAddr string) ([]byte, error) {
@@ -205,3 +304,78 @@ func (c commander) ParseAddKeyOutput(stdout, stderr string) (ibc.Wallet, error)
 func (c commander) ParseRestoreKeyOutput(stdout, stderr string) string {
 	panic("implemented in Hermes Relayer")
 }
+
+// normalizeConnections removes duplicate connections, keeping the first occurrence, and sorts them by ID.
+func normalizeConnections(conns ibc.ConnectionOutputs) ibc.ConnectionOutputs {
+	seen := make(map[string]struct{}, len(conns))
+	var unique ibc.ConnectionOutputs
+	for _, conn := range conns {
+		if _, ok := seen[conn.ID]; ok {
+			continue
+		}
+		seen[conn.ID] = struct{}{}
+		unique = append(unique, conn)
+	}
+	sort.SliceStable(unique, func(i, j int) bool {
+		return identifierLess(unique[i].ID, unique[j].ID)
+	})
+	return unique
+}
+
+// normalizeChannels removes duplicate channels, keeping the first occurrence, and sorts them by channel ID then port ID.
+func normalizeChannels(channels []ibc.ChannelOutput) []ibc.ChannelOutput {
+	seen := make(map[[2]string]struct{}, len(channels))
+	var unique []ibc.ChannelOutput
+	for _, ch := range channels {
+		key := [2]string{ch.PortID, ch.ChannelID}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		unique = append(unique, ch)
+	}
+	sort.SliceStable(unique, func(i, j int) bool {
+		if unique[i].ChannelID != unique[j].ChannelID {
+			return identifierLess(unique[i].ChannelID, unique[j].ChannelID)
+		}
+		return unique[i].PortID < unique[j].PortID
+	})
+	return unique
+}
+
+// identifierLess orders IBC identifiers such as "connection-2" and "connection-10" by prefix,
+// then numerically by their sequence suffix.
+func identifierLess(a, b string) bool {
+	aPrefix, aSeq, aOK := splitIdentifier(a)
+	bPrefix, bSeq, bOK := splitIdentifier(b)
+	if !aOK || !bOK || aPrefix != bPrefix {
+		return a < b
+	}
+	return aSeq < bSeq
+}
+
+// splitIdentifier splits an identifier into its prefix and numeric sequence suffix.
+func splitIdentifier(id string) (string, uint64, bool) {
+	i := strings.LastIndex(id, "-")
+	if i < 0 {
+		return "", 0, false
+	}
+	seq, err := strconv.ParseUint(id[i+1:], 10, 64)
+	if err != nil {
+		return "", 0, false
+	}
+	return id[:i], seq, true
+}
+
+// channelEnd identifies one end of a channel for the channel close commands.
+type channelEnd struct {
+	chainID, connectionID, portID, channelID string
+}
+
+// channelCloseCmd returns the "tx chan-close-init" or "tx chan-close-confirm" command that submits the close handshake
+// step on the dst end, with src as the counterparty.
+func (c commander) channelCloseCmd(homeDir, tx string, dst, src channelEnd) []string {
+	return c.hermesCmd(homeDir, "--json", "tx", tx,
+		"--dst-chain", dst.chainID, "--src-chain", src.chainID, "--dst-connection", dst.connectionID,
+		"--dst-port", dst.portID, "--src-port", src.portID, "--dst-channel", dst.channelID, "--src-channel", src.channelID)
+}
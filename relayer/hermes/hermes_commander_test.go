@@ -0,0 +1,204 @@
+package hermes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/relayer"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUpgradeClientCommand(t *testing.T) {
+	cmd := commander{}.UpgradeClient("gaia-1", "07-tendermint-3", 120, "/home/hermes")
+	require.Equal(t, []string{
+		"hermes", "--config", "/home/hermes/.hermes/config.toml", "--json",
+		"upgrade", "client", "--host-chain", "gaia-1", "--client", "07-tendermint-3", "--upgrade-height", "120",
+	}, cmd)
+}
+
+func TestCustomConfigPath(t *testing.T) {
+	r := &Relayer{c: &commander{log: zap.NewNop()}}
+	r.SetConfigPath("relayer/hermes.toml")
+	require.Equal(t, "relayer/hermes.toml", r.c.relativeConfigPath())
+
+	channels, err := r.c.GetChannels("gaia-1", "/home/hermes")
+	require.NoError(t, err)
+	connections, err := r.c.GetConnections("gaia-1", "/home/hermes")
+	require.NoError(t, err)
+	clients, err := r.c.GetClients("gaia-1", "/home/hermes")
+	require.NoError(t, err)
+
+	const want = "/home/hermes/relayer/hermes.toml"
+	for _, cmd := range [][]string{
+		channels,
+		connections,
+		clients,
+		r.c.QueryChainStatus("gaia-1", "/home/hermes"),
+		r.c.UpgradeClient("gaia-1", "07-tendermint-0", 10, "/home/hermes"),
+		startRelayerCmd(t, r.c, "/home/hermes", "p"),
+		r.c.hermesCmd("/home/hermes", "clear", "packets"),
+	} {
+		require.Equal(t, []string{"hermes", "--config", want}, cmd[:3])
+	}
+}
+
+func TestCustomBinary(t *testing.T) {
+	ctx := context.Background()
+
+	f := newFakeExecutor(t, map[string][]ibc.RelayerExecResult{
+		"query channels": {cannedOutput(t, "empty_list.json")},
+	})
+	r := f.relayer()
+	r.SetBinary("/opt/patched/bin/hermes")
+
+	channels, err := r.c.GetChannels("gaia-1", "/home/hermes")
+	require.NoError(t, err)
+	for _, cmd := range [][]string{
+		channels,
+		r.c.QueryChainStatus("gaia-1", "/home/hermes"),
+		startRelayerCmd(t, r.c, "/home/hermes", "p"),
+		r.c.hermesCmd("/home/hermes", "clear", "packets"),
+	} {
+		require.Equal(t, []string{"/opt/patched/bin/hermes", "--config", "/home/hermes/.hermes/config.toml"}, cmd[:3])
+	}
+
+	_, err = r.GetChannels(ctx, ibc.NopRelayerExecReporter{}, "gaia-1")
+	require.NoError(t, err)
+	require.Len(t, f.cmds, 1)
+	require.Equal(t, "/opt/patched/bin/hermes", f.cmds[0][0])
+	require.Equal(t, "hermes", r.c.Name())
+}
+
+func TestHomeLayout(t *testing.T) {
+	require.Equal(t, dotHermesLayout, layoutForVersion("1.6.0"))
+	require.Equal(t, dotHermesLayout, layoutForVersion("v0.7.0"))
+	require.Equal(t, dotHermesLayout, layoutForVersion("latest"))
+	require.Equal(t, flatLayout, layoutForVersion("v0.6.2"))
+
+	ctx := context.Background()
+	for _, tc := range []struct {
+		version    string
+		configPath string
+	}{
+		{version: DefaultContainerVersion, configPath: ".hermes/config.toml"},
+		{version: "v0.6.2", configPath: "config.toml"},
+	} {
+		f := newFakeExecutor(t, map[string][]ibc.RelayerExecResult{
+			"query": {cannedOutput(t, "empty_list.json")},
+		})
+		r := f.relayer(relayer.CustomDockerImage(defaultContainerImage, tc.version, hermesDefaultUidGid))
+		require.Equal(t, tc.configPath, r.ConfigPath())
+
+		_, err := r.GetChannels(ctx, ibc.NopRelayerExecReporter{}, "gaia-1")
+		require.NoError(t, err)
+		_, err = r.GetClients(ctx, ibc.NopRelayerExecReporter{}, "gaia-1")
+		require.NoError(t, err)
+		for _, cmd := range f.cmds {
+			require.Equal(t, []string{"hermes", "--config", "/home/hermes/" + tc.configPath}, cmd[:3])
+		}
+
+		r.SetConfigPath("custom.toml")
+		require.Equal(t, "custom.toml", r.ConfigPath())
+	}
+}
+
+func TestUnsupportedCommands(t *testing.T) {
+	// The fake executor fails the test if the unsupported command is run.
+	r := newFakeExecutor(t, nil).relayer()
+
+	err := r.UpdatePath(context.Background(), ibc.NopRelayerExecReporter{}, "p", ibc.ChannelFilter{})
+	require.ErrorIs(t, err, relayer.ErrInvalidCommand)
+	require.ErrorIs(t, err, relayer.ErrUnsupportedCapability)
+}
+
+func TestParseGetChannelsOutputClosed(t *testing.T) {
+	c := commander{log: zap.NewNop()}
+
+	channels, err := c.ParseGetChannelsOutput(string(readTestdata(t, "channels_closed.json")), "")
+	require.NoError(t, err)
+	require.Len(t, channels, 1)
+	require.Equal(t, "channel-0", channels[0].ChannelID)
+	require.Equal(t, "Closed", channels[0].State)
+	require.True(t, channels[0].IsClosed())
+}
+
+func TestParseOutputNormalization(t *testing.T) {
+	c := commander{log: zap.NewNop()}
+
+	conns, err := c.ParseGetConnectionsOutput(string(readTestdata(t, "connections_unsorted.json")), "")
+	require.NoError(t, err)
+	var connIDs []string
+	for _, conn := range conns {
+		connIDs = append(connIDs, conn.ID)
+	}
+	require.Equal(t, []string{"connection-0", "connection-2", "connection-10"}, connIDs)
+
+	channels, err := c.ParseGetChannelsOutput(string(readTestdata(t, "channels_unsorted.json")), "")
+	require.NoError(t, err)
+	var channelIDs []string
+	for _, ch := range channels {
+		channelIDs = append(channelIDs, ch.PortID+"/"+ch.ChannelID)
+	}
+	require.Equal(t, []string{"icahost/channel-1", "transfer/channel-1", "transfer/channel-11"}, channelIDs)
+}
+
+func TestParseQueryChainStatusOutput(t *testing.T) {
+	c := commander{log: zap.NewNop()}
+
+	height, err := c.ParseQueryChainStatusOutput(string(readTestdata(t, "chain_status.json", 1234)), "")
+	require.NoError(t, err)
+	require.Equal(t, uint64(1234), height)
+
+	_, err = c.ParseQueryChainStatusOutput("", "")
+	require.ErrorIs(t, err, ErrParseOutput)
+}
+
+func TestExtraStartFlags(t *testing.T) {
+	r := &Relayer{c: &commander{log: zap.NewNop()}}
+	require.NoError(t, r.SetExtraStartFlags("--full-scan"))
+	require.Equal(t, []string{"hermes", "--config", "/home/hermes/.hermes/config.toml", "start", "--full-scan"},
+		startRelayerCmd(t, r.c, "/home/hermes"))
+
+	require.ErrorContains(t, r.SetExtraStartFlags("--config", "/tmp/config.toml"), "invalid extra start flag")
+	require.Error(t, r.SetExtraStartFlags("--config=/tmp/config.toml"))
+	require.Equal(t, []string{"--full-scan"}, r.c.extraStartFlags)
+}
+
+func TestLogFormat(t *testing.T) {
+	r := &Relayer{c: &commander{log: zap.NewNop()}}
+	require.Equal(t, []string{"hermes", "--config", "/home/hermes/.hermes/config.toml", "start"}, startRelayerCmd(t, r.c, "/home/hermes", "p"))
+
+	require.NoError(t, r.SetLogFormat(LogFormatJSON))
+	require.NoError(t, r.SetExtraStartFlags("--full-scan"))
+	require.Equal(t, []string{"hermes", "--config", "/home/hermes/.hermes/config.toml", "--json", "start", "--full-scan"}, startRelayerCmd(t, r.c, "/home/hermes", "p"))
+
+	require.NoError(t, r.SetLogFormat(LogFormatPlain))
+	require.Equal(t, []string{"hermes", "--config", "/home/hermes/.hermes/config.toml", "start", "--full-scan"}, startRelayerCmd(t, r.c, "/home/hermes", "p"))
+
+	require.ErrorContains(t, r.SetLogFormat("yaml"), `invalid log format "yaml"`)
+}
+
+func TestParseErrors(t *testing.T) {
+	c := commander{log: zap.NewNop()}
+
+	_, err := c.ParseGetChannelsOutput("not json", "")
+	require.ErrorIs(t, err, ErrParseOutput)
+
+	_, err = c.ParseGetConnectionsOutput(`{"result": "oops"}`, "")
+	require.ErrorIs(t, err, ErrParseOutput)
+
+	_, err = c.ParseGetClientsOutput("", "")
+	require.ErrorIs(t, err, ErrParseOutput)
+
+	_, err = getClientIdFromStdout([]byte("garbage"))
+	require.ErrorIs(t, err, ErrParseOutput)
+
+	_, err = parseRestoreKeyOutput("ERROR key not restored")
+	require.ErrorIs(t, err, ErrParseOutput)
+
+	addr, err := parseRestoreKeyOutput("SUCCESS Restored key 'g2-2' (cosmos1czklnpzwaq3hfxtv6ne4vas2p9m5q3p3fgkz8e) on chain g2-2")
+	require.NoError(t, err)
+	require.Equal(t, "cosmos1czklnpzwaq3hfxtv6ne4vas2p9m5q3p3fgkz8e", addr)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // NewConfig returns a hermes Config with an entry for each of the provided ChainConfigs.
@@ -18,6 +19,11 @@ func NewConfig(chainConfigs ...ChainConfig) Config {
 			panic(err)
 		}
 
+		gasMultiplier := chainCfg.GasAdjustment
+		if gasMultiplier == 0 {
+			gasMultiplier = defaultGasMultiplier
+		}
+
 		chains = append(chains, Chain{
 			ID:       chainCfg.ChainID,
 			RPCAddr:  hermesCfg.rpcAddr,
@@ -27,20 +33,20 @@ func NewConfig(chainConfigs ...ChainConfig) Config {
 				Url:        strings.ReplaceAll(fmt.Sprintf("%s/websocket", hermesCfg.rpcAddr), "http", "ws"),
 				BatchDelay: "500ms"},
 			RPCTimeout:    "10s",
-			TrustedNode:   true,
+			TrustedNode:   false,
 			AccountPrefix: chainCfg.Bech32Prefix,
 			KeyName:       hermesCfg.keyName,
 			AddressType: AddressType{
 				Derivation: "cosmos",
 			},
 			StorePrefix: "ibc",
-			DefaultGas:  100000,
-			MaxGas:      400000,
+			DefaultGas:  defaultDefaultGas,
+			MaxGas:      defaultMaxGas,
 			GasPrice: GasPrice{
 				Price: gasPricesStr,
 				Denom: chainCfg.Denom,
 			},
-			GasMultiplier:  chainCfg.GasAdjustment,
+			GasMultiplier:  gasMultiplier,
 			MaxMsgNum:      30,
 			MaxTxSize:      2097152,
 			ClockDrift:     "5s",
@@ -88,6 +94,78 @@ func NewConfig(chainConfigs ...ChainConfig) Config {
 	}
 }
 
+const (
+	// defaultGasMultiplier, defaultDefaultGas and defaultMaxGas are the hermes defaults for the gas settings of a chain.
+	// The gas multiplier is only defaulted if the chain config has no gas adjustment.
+	defaultGasMultiplier = 1.1
+	defaultDefaultGas    = 100000
+	defaultMaxGas        = 400000
+
+	// loadTestMaxMsgNum is the maximum number of messages per transaction with the load test preset, which is the
+	// largest value hermes accepts.
+	loadTestMaxMsgNum = 100
+
+	// loadTestMaxGas is the gas limit of transactions with the load test preset, enough for loadTestMaxMsgNum packets.
+	loadTestMaxGas = 10000000
+
+	// telemetryPort is the port the hermes telemetry server listens on when enabled.
+	telemetryPort = 3001
+)
+
+// applyLoadTestPreset overrides the given config with the settings of Relayer.EnableLoadTestPreset.
+func applyLoadTestPreset(cfg *Config) {
+	cfg.Mode.Packets.ClearInterval = 0
+	cfg.Mode.Packets.TxConfirmation = true
+	for i := range cfg.Chains {
+		cfg.Chains[i].MaxMsgNum = loadTestMaxMsgNum
+		cfg.Chains[i].MaxGas = loadTestMaxGas
+		cfg.Chains[i].EventSource.BatchDelay = "50ms"
+	}
+}
+
+// chainSettings holds per chain overrides of the generated hermes config, keyed by chain ID on the Relayer.
+type chainSettings struct {
+	feeGranter  string
+	memoPrefix  *string
+	trustedNode bool
+	clockDrift  time.Duration
+	keyName     string
+
+	// gasMultiplier, defaultGas and maxGas override the gas settings of the chain when non-zero.
+	gasMultiplier float64
+	defaultGas    int
+	maxGas        int
+
+	addressType *AddressType
+}
+
+// apply overrides the values in the given chain entry with any configured settings.
+func (s chainSettings) apply(chain *Chain) {
+	chain.FeeGranter = s.feeGranter
+	chain.TrustedNode = s.trustedNode
+	if s.memoPrefix != nil {
+		chain.MemoPrefix = *s.memoPrefix
+	}
+	if s.keyName != "" {
+		chain.KeyName = s.keyName
+	}
+	if s.clockDrift != 0 {
+		chain.ClockDrift = hermesDuration(s.clockDrift)
+	}
+	if s.gasMultiplier != 0 {
+		chain.GasMultiplier = s.gasMultiplier
+	}
+	if s.defaultGas != 0 {
+		chain.DefaultGas = s.defaultGas
+	}
+	if s.maxGas != 0 {
+		chain.MaxGas = s.maxGas
+	}
+	if s.addressType != nil {
+		chain.AddressType = *s.addressType
+	}
+}
+
 type Config struct {
 	Global    Global    `toml:"global"`
 	Mode      Mode      `toml:"mode"`
@@ -141,7 +219,25 @@ type Telemetry struct {
 }
 
 type AddressType struct {
-	Derivation string `toml:"derivation"`
+	Derivation string     `toml:"derivation"`
+	ProtoType  *ProtoType `toml:"proto_type,omitempty"`
+}
+
+type ProtoType struct {
+	PkType string `toml:"pk_type"`
+}
+
+// defaultEthermintPkType is the type of the public keys of accounts on ethermint chains.
+const defaultEthermintPkType = "/ethermint.crypto.v1.ethsecp256k1.PubKey"
+
+// EthermintAddressType returns the address type of ethermint chains, whose keys are derived with coin type 60.
+// pkType is the protobuf type of the public keys of the chain, e.g. "/injective.crypto.v1beta1.ethsecp256k1.PubKey";
+// if empty, the ethermint type is used.
+func EthermintAddressType(pkType string) AddressType {
+	if pkType == "" {
+		pkType = defaultEthermintPkType
+	}
+	return AddressType{Derivation: "ethermint", ProtoType: &ProtoType{PkType: pkType}}
 }
 
 type GasPrice struct {
@@ -182,4 +278,5 @@ type Chain struct {
 	TrustingPeriod string         `toml:"trusting_period"`
 	TrustThreshold TrustThreshold `toml:"trust_threshold"`
 	MemoPrefix     string         `toml:"memo_prefix,omitempty"`
+	FeeGranter     string         `toml:"fee_granter,omitempty"`
 }
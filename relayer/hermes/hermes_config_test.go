@@ -0,0 +1,295 @@
+package hermes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pelletier/go-toml"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderConfigOverride(t *testing.T) {
+	const override = `[[chains]]
+id = 'gaia-1'
+rpc_addr = '{{ rpc "gaia-1" }}'
+grpc_addr = '{{ grpc "gaia-1" }}'
+
+[[chains]]
+id = 'osmosis-1'
+rpc_addr = '{{ rpc "osmosis-1" }}'
+`
+	gaia := ChainConfig{cfg: ibc.ChainConfig{ChainID: "gaia-1"}, rpcAddr: "http://gaia-val:26657", grpcAddr: "gaia-val:9090"}
+	osmosis := ChainConfig{cfg: ibc.ChainConfig{ChainID: "osmosis-1"}, rpcAddr: "http://osmo-val:26657", grpcAddr: "osmo-val:9090"}
+
+	t.Run("partial", func(t *testing.T) {
+		bz, complete, err := renderConfigOverride([]byte(override), []ChainConfig{gaia})
+		require.NoError(t, err)
+		require.False(t, complete)
+		require.Contains(t, string(bz), "rpc_addr = 'http://gaia-val:26657'")
+		require.Contains(t, string(bz), "grpc_addr = 'http://gaia-val:9090'")
+	})
+
+	t.Run("complete", func(t *testing.T) {
+		bz, complete, err := renderConfigOverride([]byte(override), []ChainConfig{gaia, osmosis})
+		require.NoError(t, err)
+		require.True(t, complete)
+		require.Contains(t, string(bz), "rpc_addr = 'http://osmo-val:26657'")
+	})
+
+	t.Run("verbatim", func(t *testing.T) {
+		const plain = "[global]\nlog_level = 'debug'\n"
+		bz, complete, err := renderConfigOverride([]byte(plain), nil)
+		require.NoError(t, err)
+		require.True(t, complete)
+		require.Equal(t, plain, string(bz))
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		_, _, err := renderConfigOverride([]byte(`{{ rpc `), nil)
+		require.Error(t, err)
+	})
+}
+
+func TestChainSettingsConfig(t *testing.T) {
+	r := &Relayer{}
+	r.SetFeeGranter("gaia-1", "cosmos1granter")
+	r.SetMemoPrefix("gaia-1", "")
+	r.SetTrustedNode("gaia-1", true)
+	r.SetClockDrift("gaia-1", 90*time.Second)
+
+	_, err := r.configContent(ibc.ChainConfig{ChainID: "gaia-1", Denom: "uatom", GasPrices: "0.01uatom"}, "relayer", "http://rpc:26657", "grpc:9090")
+	require.NoError(t, err)
+	bz, err := r.configContent(ibc.ChainConfig{ChainID: "osmosis-1", Denom: "uosmo", GasPrices: "0.01uosmo"}, "relayer", "http://rpc:26657", "grpc:9090")
+	require.NoError(t, err)
+
+	var cfg Config
+	require.NoError(t, toml.Unmarshal(bz, &cfg))
+	require.Len(t, cfg.Chains, 2)
+
+	require.Equal(t, "cosmos1granter", cfg.Chains[0].FeeGranter)
+	require.Empty(t, cfg.Chains[0].MemoPrefix)
+	require.True(t, cfg.Chains[0].TrustedNode)
+	require.Equal(t, "90000ms", cfg.Chains[0].ClockDrift)
+
+	require.Empty(t, cfg.Chains[1].FeeGranter)
+	require.Equal(t, "hermes", cfg.Chains[1].MemoPrefix)
+	require.False(t, cfg.Chains[1].TrustedNode)
+	require.Equal(t, "5s", cfg.Chains[1].ClockDrift)
+}
+
+func TestGasSettingsConfig(t *testing.T) {
+	r := &Relayer{}
+	r.SetGasMultiplier("gaia-1", 1.5)
+	r.SetGasLimits("gaia-1", 200000, 0)
+	r.SetGasLimits("juno-1", 0, 800000)
+
+	_, err := r.configContent(ibc.ChainConfig{ChainID: "gaia-1", Denom: "uatom", GasPrices: "0.01uatom", GasAdjustment: 1.3}, "relayer", "http://rpc:26657", "grpc:9090")
+	require.NoError(t, err)
+	_, err = r.configContent(ibc.ChainConfig{ChainID: "osmosis-1", Denom: "uosmo", GasPrices: "0.01uosmo", GasAdjustment: 1.3}, "relayer", "http://rpc:26657", "grpc:9090")
+	require.NoError(t, err)
+	bz, err := r.configContent(ibc.ChainConfig{ChainID: "juno-1", Denom: "ujuno", GasPrices: "0.01ujuno"}, "relayer", "http://rpc:26657", "grpc:9090")
+	require.NoError(t, err)
+
+	var cfg Config
+	require.NoError(t, toml.Unmarshal(bz, &cfg))
+	require.Len(t, cfg.Chains, 3)
+
+	require.Equal(t, 1.5, cfg.Chains[0].GasMultiplier)
+	require.Equal(t, 200000, cfg.Chains[0].DefaultGas)
+	require.Equal(t, 400000, cfg.Chains[0].MaxGas)
+
+	require.Equal(t, 1.3, cfg.Chains[1].GasMultiplier)
+	require.Equal(t, 100000, cfg.Chains[1].DefaultGas)
+	require.Equal(t, 400000, cfg.Chains[1].MaxGas)
+
+	require.Equal(t, 1.1, cfg.Chains[2].GasMultiplier)
+	require.Equal(t, 100000, cfg.Chains[2].DefaultGas)
+	require.Equal(t, 800000, cfg.Chains[2].MaxGas)
+}
+
+func TestAddressTypeConfig(t *testing.T) {
+	r := &Relayer{}
+	r.SetAddressType("evmos_9001-1", EthermintAddressType(""))
+	r.SetAddressType("injective-1", EthermintAddressType("/injective.crypto.v1beta1.ethsecp256k1.PubKey"))
+
+	_, err := r.configContent(ibc.ChainConfig{ChainID: "gaia-1", Denom: "uatom", GasPrices: "0.01uatom"}, "relayer", "http://rpc:26657", "grpc:9090")
+	require.NoError(t, err)
+	_, err = r.configContent(ibc.ChainConfig{ChainID: "evmos_9001-1", Denom: "aevmos", GasPrices: "0.01aevmos"}, "relayer", "http://rpc:26657", "grpc:9090")
+	require.NoError(t, err)
+	bz, err := r.configContent(ibc.ChainConfig{ChainID: "injective-1", Denom: "inj", GasPrices: "0.01inj"}, "relayer", "http://rpc:26657", "grpc:9090")
+	require.NoError(t, err)
+
+	require.Contains(t, string(bz), "/ethermint.crypto.v1.ethsecp256k1.PubKey")
+
+	var cfg Config
+	require.NoError(t, toml.Unmarshal(bz, &cfg))
+	require.Len(t, cfg.Chains, 3)
+	require.Equal(t, AddressType{Derivation: "cosmos"}, cfg.Chains[0].AddressType)
+	require.Equal(t, AddressType{Derivation: "ethermint", ProtoType: &ProtoType{PkType: "/ethermint.crypto.v1.ethsecp256k1.PubKey"}}, cfg.Chains[1].AddressType)
+	require.Equal(t, AddressType{Derivation: "ethermint", ProtoType: &ProtoType{PkType: "/injective.crypto.v1beta1.ethsecp256k1.PubKey"}}, cfg.Chains[2].AddressType)
+}
+
+func TestKeyNameConfig(t *testing.T) {
+	r := &Relayer{}
+	r.SetKeyName("osmosis-1", "osmosis-signer")
+
+	_, err := r.configContent(ibc.ChainConfig{ChainID: "gaia-1", Denom: "uatom", GasPrices: "0.01uatom"}, "gaia", "http://rpc:26657", "grpc:9090")
+	require.NoError(t, err)
+	bz, err := r.configContent(ibc.ChainConfig{ChainID: "osmosis-1", Denom: "uosmo", GasPrices: "0.01uosmo"}, "osmosis", "http://rpc:26657", "grpc:9090")
+	require.NoError(t, err)
+
+	var cfg Config
+	require.NoError(t, toml.Unmarshal(bz, &cfg))
+	require.Len(t, cfg.Chains, 2)
+	require.Equal(t, "gaia", cfg.Chains[0].KeyName)
+	require.Equal(t, "osmosis-signer", cfg.Chains[1].KeyName)
+
+	r.recordKey("osmosis-1", "osmosis")
+	require.EqualError(t, r.checkKeys(), "key osmosis-signer selected for chain osmosis-1 has not been restored")
+	r.recordKey("osmosis-1", "osmosis-signer")
+	require.NoError(t, r.checkKeys())
+}
+
+func TestTimeoutSettings(t *testing.T) {
+	r := &Relayer{}
+	bz, err := r.configContent(ibc.ChainConfig{ChainID: "gaia-1", Denom: "uatom", GasPrices: "0.01uatom"}, "relayer", "http://rpc:26657", "grpc:9090")
+	require.NoError(t, err)
+	var cfg Config
+	require.NoError(t, toml.Unmarshal(bz, &cfg))
+	require.Equal(t, "10s", cfg.Chains[0].RPCTimeout)
+	require.Equal(t, "30s", cfg.Chains[0].MaxBlockTime)
+
+	r = &Relayer{}
+	r.SetRPCTimeout(90 * time.Second)
+	r.SetMaxBlockTime(time.Minute)
+	_, err = r.configContent(ibc.ChainConfig{ChainID: "gaia-1", Denom: "uatom", GasPrices: "0.01uatom"}, "relayer", "http://rpc:26657", "grpc:9090")
+	require.NoError(t, err)
+	bz, err = r.configContent(ibc.ChainConfig{ChainID: "osmosis-1", Denom: "uosmo", GasPrices: "0.01uosmo"}, "relayer", "http://rpc:26657", "grpc:9090")
+	require.NoError(t, err)
+	cfg = Config{}
+	require.NoError(t, toml.Unmarshal(bz, &cfg))
+	require.Len(t, cfg.Chains, 2)
+	for _, chain := range cfg.Chains {
+		require.Equal(t, "90000ms", chain.RPCTimeout)
+		require.Equal(t, "60000ms", chain.MaxBlockTime)
+	}
+}
+
+func TestPacketLogging(t *testing.T) {
+	r := &Relayer{}
+	bz, err := r.configContent(ibc.ChainConfig{ChainID: "gaia-1", Denom: "uatom", GasPrices: "0.01uatom"}, "relayer", "http://rpc:26657", "grpc:9090")
+	require.NoError(t, err)
+	var cfg Config
+	require.NoError(t, toml.Unmarshal(bz, &cfg))
+	require.Equal(t, "info", cfg.Global.LogLevel)
+
+	r = &Relayer{}
+	r.EnablePacketLogging()
+	bz, err = r.configContent(ibc.ChainConfig{ChainID: "gaia-1", Denom: "uatom", GasPrices: "0.01uatom"}, "relayer", "http://rpc:26657", "grpc:9090")
+	require.NoError(t, err)
+	require.NoError(t, toml.Unmarshal(bz, &cfg))
+	require.Equal(t, "trace", cfg.Global.LogLevel)
+
+	const logs = `2023-09-26T10:00:00Z TRACE packet=seq:5, path:channel-0/transfer->channel-1/transfer, toh:no timeout, tos:1970-01-01T00:00:00Z
+2023-09-26T10:00:01Z TRACE packet=seq:15, path:channel-0/transfer->channel-1/transfer
+2023-09-26T10:00:02Z INFO pulled packet data for 1 events; events.total=1 events.left=0
+2023-09-26T10:00:03Z DEBUG {"sequence":"5","source_port":"transfer"}`
+	lines := packetLogLines(logs, 5)
+	require.Len(t, lines, 2)
+	require.Contains(t, lines[0], "seq:5,")
+	require.Contains(t, lines[1], `"sequence":"5"`)
+}
+
+func TestLoadTestPreset(t *testing.T) {
+	r := &Relayer{}
+	r.EnableLoadTestPreset()
+	_, err := r.configContent(ibc.ChainConfig{ChainID: "gaia-1", Denom: "uatom", GasPrices: "0.01uatom"}, "relayer", "http://rpc:26657", "grpc:9090")
+	require.NoError(t, err)
+	bz, err := r.configContent(ibc.ChainConfig{ChainID: "osmosis-1", Denom: "uosmo", GasPrices: "0.01uosmo"}, "relayer", "http://rpc:26657", "grpc:9090")
+	require.NoError(t, err)
+
+	var cfg Config
+	require.NoError(t, toml.Unmarshal(bz, &cfg))
+	require.Equal(t, 0, cfg.Mode.Packets.ClearInterval)
+	require.True(t, cfg.Mode.Packets.TxConfirmation)
+	require.Equal(t, Telemetry{Enabled: true, Host: "0.0.0.0", Port: 3001}, cfg.Telemetry)
+	require.Len(t, cfg.Chains, 2)
+	for _, chain := range cfg.Chains {
+		require.Equal(t, 100, chain.MaxMsgNum)
+		require.Equal(t, 10000000, chain.MaxGas)
+		require.Equal(t, "50ms", chain.EventSource.BatchDelay)
+	}
+}
+
+func TestEnableTelemetry(t *testing.T) {
+	chainCfg := ibc.ChainConfig{ChainID: "gaia-1", Denom: "uatom", GasPrices: "0.01uatom"}
+
+	r := &Relayer{}
+	bz, err := r.configContent(chainCfg, "relayer", "http://rpc:26657", "grpc:9090")
+	require.NoError(t, err)
+	var cfg Config
+	require.NoError(t, toml.Unmarshal(bz, &cfg))
+	require.False(t, cfg.Telemetry.Enabled)
+
+	// Telemetry is enabled without the rest of the load test preset.
+	r = &Relayer{}
+	r.EnableTelemetry()
+	bz, err = r.configContent(chainCfg, "relayer", "http://rpc:26657", "grpc:9090")
+	require.NoError(t, err)
+	cfg = Config{}
+	require.NoError(t, toml.Unmarshal(bz, &cfg))
+	require.Equal(t, Telemetry{Enabled: true, Host: "0.0.0.0", Port: 3001}, cfg.Telemetry)
+	require.False(t, cfg.Mode.Packets.TxConfirmation)
+	require.Equal(t, defaultMaxGas, cfg.Chains[0].MaxGas)
+}
+
+func TestTxConfirmationConfig(t *testing.T) {
+	chainCfg := ibc.ChainConfig{ChainID: "gaia-1", Denom: "uatom", GasPrices: "0.01uatom"}
+
+	r := &Relayer{}
+	bz, err := r.configContent(chainCfg, "relayer", "http://rpc:26657", "grpc:9090")
+	require.NoError(t, err)
+	require.Contains(t, string(bz), "tx_confirmation = false")
+
+	r = &Relayer{}
+	r.SetTxConfirmation(true)
+	bz, err = r.configContent(chainCfg, "relayer", "http://rpc:26657", "grpc:9090")
+	require.NoError(t, err)
+	require.Contains(t, string(bz), "tx_confirmation = true")
+
+	r = &Relayer{}
+	r.EnableLoadTestPreset()
+	r.SetTxConfirmation(false)
+	bz, err = r.configContent(chainCfg, "relayer", "http://rpc:26657", "grpc:9090")
+	require.NoError(t, err)
+	var cfg Config
+	require.NoError(t, toml.Unmarshal(bz, &cfg))
+	require.False(t, cfg.Mode.Packets.TxConfirmation)
+}
+
+func TestSetDirectionalMemos(t *testing.T) {
+	r := &Relayer{}
+	require.ErrorContains(t, r.SetDirectionalMemos("gaia-1", "gaia-1", "a", "b"), "itself")
+	require.NoError(t, r.SetDirectionalMemos("gaia-1", "osmosis-1", "gaia-to-osmosis", "osmosis-to-gaia"))
+	require.NoError(t, r.SetDirectionalMemos("gaia-1", "juno-1", "", "juno-to-gaia"))
+
+	var bz []byte
+	for _, chain := range []ibc.ChainConfig{
+		{ChainID: "gaia-1", Denom: "uatom", GasPrices: "0.01uatom"},
+		{ChainID: "osmosis-1", Denom: "uosmo", GasPrices: "0.01uosmo"},
+		{ChainID: "juno-1", Denom: "ujuno", GasPrices: "0.01ujuno"},
+	} {
+		var err error
+		bz, err = r.configContent(chain, "relayer", "http://rpc:26657", "grpc:9090")
+		require.NoError(t, err)
+	}
+
+	var cfg Config
+	require.NoError(t, toml.Unmarshal(bz, &cfg))
+	require.Len(t, cfg.Chains, 3)
+	// Transactions submitted on a chain relay from its counterparty.
+	require.Equal(t, "juno-to-gaia", cfg.Chains[0].MemoPrefix)
+	require.Equal(t, "gaia-to-osmosis", cfg.Chains[1].MemoPrefix)
+	require.Equal(t, "hermes", cfg.Chains[2].MemoPrefix)
+}
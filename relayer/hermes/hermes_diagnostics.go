@@ -0,0 +1,88 @@
+package hermes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"go.uber.org/multierr"
+
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+)
+
+// diagnosticKey is the JSON representation of a key in the keys diagnostics file.
+type diagnosticKey struct {
+	KeyName string `json:"key_name"`
+	Address string `json:"address"`
+}
+
+// DumpDiagnostics writes the state of the relayer to files in dir, which is created if necessary,
+// so that it can be uploaded as a CI artifact when a test fails. The following files are written:
+//
+//   - relayer.log: the logs of the relayer process started through StartRelayer
+//   - config.toml: the hermes config file
+//   - config.annotated.toml: the generated config annotated with the source of each setting, see AnnotatedConfig
+//   - keys-<chain ID>.json, channels-<chain ID>.json and connections-<chain ID>.json for every configured chain
+//
+// Failing to collect any of them does not prevent collecting the others.
+// The returned error combines every failure, e.g. logs are not available if the relayer was never started.
+func (r *Relayer) DumpDiagnostics(ctx context.Context, rep ibc.RelayerExecReporter, dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create diagnostics directory: %w", err)
+	}
+
+	var merr error
+	write := func(name string, collect func() ([]byte, error)) {
+		content, err := collect()
+		if err != nil {
+			multierr.AppendInto(&merr, fmt.Errorf("%s: %w", name, err))
+			return
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), content, 0o644); err != nil {
+			multierr.AppendInto(&merr, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+
+	write("relayer.log", func() ([]byte, error) {
+		logs, err := r.Logs(ctx)
+		return []byte(logs), err
+	})
+	write("config.toml", func() ([]byte, error) {
+		return r.ReadFileFromHomeDir(ctx, r.c.relativeConfigPath())
+	})
+	if r.configOverride == nil {
+		write("config.annotated.toml", r.AnnotatedConfig)
+	}
+
+	for _, chainID := range r.ConfiguredChains() {
+		write("keys-"+chainID+".json", func() ([]byte, error) {
+			wallets, err := r.ListKeys(ctx, rep, chainID)
+			if err != nil {
+				return nil, err
+			}
+			keys := make([]diagnosticKey, len(wallets))
+			for i, w := range wallets {
+				keys[i] = diagnosticKey{KeyName: w.KeyName(), Address: w.FormattedAddress()}
+			}
+			return json.MarshalIndent(keys, "", "  ")
+		})
+		write("channels-"+chainID+".json", func() ([]byte, error) {
+			channels, err := r.GetChannels(ctx, rep, chainID)
+			if err != nil {
+				return nil, err
+			}
+			return json.MarshalIndent(channels, "", "  ")
+		})
+		write("connections-"+chainID+".json", func() ([]byte, error) {
+			connections, err := r.GetConnections(ctx, rep, chainID)
+			if err != nil {
+				return nil, err
+			}
+			return json.MarshalIndent(connections, "", "  ")
+		})
+	}
+
+	return merr
+}
@@ -0,0 +1,47 @@
+package hermes
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/relayer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDumpDiagnostics(t *testing.T) {
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "artifacts")
+
+	f := newFakeExecutor(t, map[string][]ibc.RelayerExecResult{
+		"keys list":      {cannedOutput(t, "keys_list.json")},
+		"query channels": {cannedOutput(t, "channels_transfer.json")},
+		"":               {{Err: fmt.Errorf("exit code 1: rpc error"), ExitCode: 1}},
+	})
+	r := f.relayer()
+	r.chainConfigs = []ChainConfig{{cfg: ibc.ChainConfig{ChainID: "gaia-1"}}}
+
+	err := r.DumpDiagnostics(ctx, ibc.NopRelayerExecReporter{}, dir)
+	require.ErrorContains(t, err, "relayer.log: relayer not started")
+	require.ErrorIs(t, err, relayer.ErrNoDocker)
+	require.ErrorContains(t, err, "connections-gaia-1.json: ")
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	require.Equal(t, []string{"channels-gaia-1.json", "keys-gaia-1.json"}, names)
+
+	keys, err := os.ReadFile(filepath.Join(dir, "keys-gaia-1.json"))
+	require.NoError(t, err)
+	require.Contains(t, string(keys), `"address": "cosmos1czklnpzwaq3hfxtv6ne4vas2p9m5q3p3fgkz8e"`)
+
+	channels, err := os.ReadFile(filepath.Join(dir, "channels-gaia-1.json"))
+	require.NoError(t, err)
+	require.Contains(t, string(channels), `"channel_id": "channel-0"`)
+}
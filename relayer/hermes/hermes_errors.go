@@ -0,0 +1,35 @@
+package hermes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+)
+
+var (
+	// ErrParseOutput is returned when the output of a hermes command cannot be parsed.
+	ErrParseOutput = errors.New("failed to parse hermes output")
+
+	// ErrHermesCommand is returned when a hermes command fails to execute or exits non-zero.
+	ErrHermesCommand = errors.New("hermes command failed")
+
+	// ErrPathNotFound is returned when a path is used before it has been created through GeneratePath.
+	ErrPathNotFound = errors.New("path not found")
+)
+
+// parseError wraps err so that it matches ErrParseOutput, noting what was being parsed.
+func parseError(what string, err error) error {
+	return fmt.Errorf("%w: %s: %w", ErrParseOutput, what, err)
+}
+
+// exec runs the given hermes command, wrapping any failure so that it matches ErrHermesCommand.
+func (r *Relayer) exec(ctx context.Context, rep ibc.RelayerExecReporter, cmd []string) ibc.RelayerExecResult {
+	res := r.Exec(ctx, rep, cmd, nil)
+	if res.Err != nil {
+		res.Err = fmt.Errorf("%w: %s: %w", ErrHermesCommand, strings.Join(cmd, " "), res.Err)
+	}
+	return res
+}
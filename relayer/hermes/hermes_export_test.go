@@ -0,0 +1,43 @@
+package hermes_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v8"
+	"github.com/strangelove-ventures/interchaintest/v8/relayer/hermes"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestExportState(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	client, network := interchaintest.DockerSetup(t)
+	ctx := context.Background()
+
+	r := hermes.NewHermesRelayer(zaptest.NewLogger(t), t.Name(), client, network)
+	require.NoError(t, r.WriteFileToHomeDir(ctx, ".hermes/config.toml", []byte("[global]\nlog_level = 'info'\n")))
+
+	bz, err := r.ExportState(ctx)
+	require.NoError(t, err)
+
+	var names []string
+	tr := tar.NewReader(bytes.NewReader(bz))
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		names = append(names, hdr.Name)
+	}
+	require.Contains(t, names, "dockervolume/.hermes/config.toml")
+}
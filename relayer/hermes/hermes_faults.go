@@ -0,0 +1,73 @@
+package hermes
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+)
+
+// DropPackets makes Flush and RelayOnce skip the packets with the given sequences, as if they were lost,
+// so that tests can exercise timeouts of packets that are never relayed. Calling it again replaces the
+// dropped sequences, and calling it without sequences relays every packet again.
+//
+// Packets are only dropped when relaying through Flush and RelayOnce; a relayer started through StartRelayer
+// relays every packet. Dropping packets requires hermes v1.7.0 or later, for the --packet-sequences flag.
+func (r *Relayer) DropPackets(sequences ...uint64) {
+	r.droppedSequences = slices.Clone(sequences)
+}
+
+// clearPacketsCmd returns the command that relays the pending packets and acknowledgements of the given channel
+// end in both directions, except for any dropped packets. If every pending packet is dropped, nil is returned.
+func (r *Relayer) clearPacketsCmd(ctx context.Context, rep ibc.RelayerExecReporter, chainID, portID, channelID string) ([]string, error) {
+	cmd := r.c.hermesCmd(r.HomeDir(), "clear", "packets", "--chain", chainID, "--channel", channelID, "--port", portID)
+	if len(r.droppedSequences) == 0 {
+		return cmd, nil
+	}
+
+	pending, err := r.pendingSequences(ctx, rep, chainID, portID, channelID)
+	if err != nil {
+		return nil, err
+	}
+	var sequences []string
+	for _, seq := range pending {
+		if !slices.Contains(r.droppedSequences, seq) {
+			sequences = append(sequences, strconv.FormatUint(seq, 10))
+		}
+	}
+	if len(sequences) == 0 {
+		return nil, nil
+	}
+	return append(cmd, "--packet-sequences", strings.Join(sequences, ",")), nil
+}
+
+// pendingSequences returns the sorted sequences of the packets and acknowledgements pending in either direction
+// on the given channel end.
+func (r *Relayer) pendingSequences(ctx context.Context, rep ibc.RelayerExecReporter, chainID, portID, channelID string) ([]uint64, error) {
+	var resp PendingPacketsResponse
+	if err := r.queryPackets(ctx, rep, "pending", chainID, portID, channelID, &resp); err != nil {
+		return nil, err
+	}
+	var sequences []uint64
+	for _, pending := range []PendingPackets{resp.Result.Src, resp.Result.Dst} {
+		sequences = append(sequences, pending.UnreceivedPackets...)
+		sequences = append(sequences, pending.UnreceivedAcks...)
+	}
+	slices.Sort(sequences)
+	return slices.Compact(sequences), nil
+}
+
+// clearPackets relays the pending packets of the given channel end with clearPacketsCmd.
+func (r *Relayer) clearPackets(ctx context.Context, rep ibc.RelayerExecReporter, chainID, portID, channelID string) error {
+	cmd, err := r.clearPacketsCmd(ctx, rep, chainID, portID, channelID)
+	if err != nil {
+		return fmt.Errorf("failed to determine packets to relay on %s/%s: %w", portID, channelID, err)
+	}
+	if cmd == nil {
+		return nil
+	}
+	return r.exec(ctx, rep, cmd).Err
+}
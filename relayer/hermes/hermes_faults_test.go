@@ -0,0 +1,35 @@
+package hermes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDropPackets(t *testing.T) {
+	ctx := context.Background()
+
+	f := newFakeExecutor(t, map[string][]ibc.RelayerExecResult{
+		"query packet pending": {cannedOutput(t, "packet_pending.json")},
+		"clear packets":        {cannedOutput(t, "clear_packets.txt")},
+	})
+	r := f.relayer()
+	require.NoError(t, r.GeneratePath(ctx, ibc.NopRelayerExecReporter{}, "gaia-1", "osmosis-1", "p"))
+	r.paths["p"].chainA.portID = "transfer"
+
+	r.DropPackets(2, 4)
+	require.NoError(t, r.Flush(ctx, ibc.NopRelayerExecReporter{}, "p", "channel-0"))
+
+	r.DropPackets(1, 2, 3, 4, 7, 8, 9)
+	require.NoError(t, r.Flush(ctx, ibc.NopRelayerExecReporter{}, "p", "channel-0"))
+
+	r.DropPackets()
+	require.NoError(t, r.Flush(ctx, ibc.NopRelayerExecReporter{}, "p", "channel-0"))
+
+	require.Equal(t, [][]string{
+		{"clear", "packets", "--chain", "gaia-1", "--channel", "channel-0", "--port", "transfer", "--packet-sequences", "1,3,7,8,9"},
+		{"clear", "packets", "--chain", "gaia-1", "--channel", "channel-0", "--port", "transfer"},
+	}, f.commands("clear packets"))
+}
@@ -0,0 +1,201 @@
+package hermes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+)
+
+// ChainHealth is the health of a chain as seen by the relayer, as reported by HealthCheck.
+type ChainHealth struct {
+	// RPCReachable reports whether hermes could connect to the RPC endpoint of the chain.
+	RPCReachable bool
+	// GRPCReachable reports whether hermes could connect to the gRPC endpoint of the chain.
+	// Hermes only checks the gRPC endpoint once the RPC endpoint is reachable, so GRPCReachable is false
+	// whenever RPCReachable is, whether or not the gRPC endpoint would have been reachable.
+	GRPCReachable bool
+	// Healthy reports whether the chain passed the hermes health check, e.g. its node is synced
+	// and its SDK and ibc-go versions are compatible.
+	Healthy bool
+	// KeyPresent reports whether the key hermes signs transactions on the chain with has been restored.
+	KeyPresent bool
+	// Funded reports whether the signing key has a positive balance of the gas denom of the chain.
+	Funded bool
+	// Problem describes why the chain is unreachable or unhealthy, if hermes reported a reason.
+	Problem string
+}
+
+// OK reports whether the relayer is able to relay on the chain.
+func (h ChainHealth) OK() bool {
+	return h.RPCReachable && h.GRPCReachable && h.Healthy && h.KeyPresent && h.Funded
+}
+
+// healthCheckLog is a hermes log line in the JSON format, e.g.
+// {"level":"INFO","fields":{"message":"chain is healthy"},"span":{"chain":"gaia-1","name":"health_check"}}
+type healthCheckLog struct {
+	Fields struct {
+		Message string `json:"message"`
+	} `json:"fields"`
+	Span struct {
+		Chain string `json:"chain"`
+	} `json:"span"`
+}
+
+// KeyBalanceResponse contains the balance of a key, as output by "keys balance".
+type KeyBalanceResponse struct {
+	Result struct {
+		Amount string `json:"amount"`
+		Denom  string `json:"denom"`
+	} `json:"result"`
+}
+
+// HealthCheck runs the hermes health check and reports the health of every configured chain, keyed by chain ID,
+// together with whether the key hermes signs with on the chain is present and funded.
+// It is intended for validating the relayer setup before starting it.
+func (r *Relayer) HealthCheck(ctx context.Context, rep ibc.RelayerExecReporter) (map[string]ChainHealth, error) {
+	res := r.exec(ctx, rep, r.c.hermesCmd(r.HomeDir(), "--json", "health-check"))
+	if res.Err != nil {
+		return nil, res.Err
+	}
+	// Hermes logs the per chain results to stderr.
+	reported := parseHealthCheckOutput(append(append([]byte{}, res.Stdout...), res.Stderr...))
+
+	health := make(map[string]ChainHealth, len(r.chainConfigs))
+	for _, c := range r.chainConfigs {
+		chainID := c.cfg.ChainID
+		h, ok := reported[chainID]
+		if !ok {
+			h.Problem = "not reported by the health check"
+		}
+
+		keyName := r.signingKey(c)
+		h.KeyPresent = r.HasKey(chainID, keyName)
+		if h.KeyPresent && h.GRPCReachable {
+			balance, err := r.keyBalance(ctx, rep, chainID, keyName, "")
+			if err != nil {
+				return nil, err
+			}
+			h.Funded = balance.IsPositive()
+		}
+		health[chainID] = h
+	}
+	return health, nil
+}
+
+// AssertMinBalance checks that the key hermes signs with on each of the given chains holds at least the given coin,
+// keyed by chain ID, so that the relayer does not run out of gas mid-test.
+// Only the denom of each coin is queried, and every chain is checked before returning, so that a single error
+// lists every chain whose key holds less than required, or that is not configured.
+func (r *Relayer) AssertMinBalance(ctx context.Context, rep ibc.RelayerExecReporter, minBalances map[string]sdk.Coin) error {
+	chainIDs := make([]string, 0, len(minBalances))
+	for chainID := range minBalances {
+		chainIDs = append(chainIDs, chainID)
+	}
+	sort.Strings(chainIDs)
+
+	var deficits []string
+	for _, chainID := range chainIDs {
+		want := minBalances[chainID]
+		i := slices.IndexFunc(r.chainConfigs, func(c ChainConfig) bool { return c.cfg.ChainID == chainID })
+		if i < 0 {
+			deficits = append(deficits, fmt.Sprintf("chain %s is not configured", chainID))
+			continue
+		}
+		keyName := r.signingKey(r.chainConfigs[i])
+		balance, err := r.keyBalance(ctx, rep, chainID, keyName, want.Denom)
+		if err != nil {
+			return fmt.Errorf("failed to query balance of key %s on %s: %w", keyName, chainID, err)
+		}
+		if balance.LT(want.Amount) {
+			deficits = append(deficits, fmt.Sprintf("key %s on %s holds %s%s, needs %s (short %s%s)",
+				keyName, chainID, balance, want.Denom, want, want.Amount.Sub(balance), want.Denom))
+		}
+	}
+	if len(deficits) > 0 {
+		return fmt.Errorf("relayer keys below minimum balance: %s", strings.Join(deficits, "; "))
+	}
+	return nil
+}
+
+// signingKey returns the name of the key hermes signs with on the given chain.
+func (r *Relayer) signingKey(c ChainConfig) string {
+	if settings, ok := r.chainSettings[c.cfg.ChainID]; ok && settings.keyName != "" {
+		return settings.keyName
+	}
+	return c.keyName
+}
+
+// keyBalance returns the balance of the given denom held by the named key on the given chain.
+// If denom is empty, the balance of the gas denom is returned.
+func (r *Relayer) keyBalance(ctx context.Context, rep ibc.RelayerExecReporter, chainID, keyName, denom string) (math.Int, error) {
+	cmd := r.c.hermesCmd(r.HomeDir(), "--json", "keys", "balance", "--chain", chainID, "--key-name", keyName)
+	if denom != "" {
+		cmd = append(cmd, "--denom", denom)
+	}
+	res := r.exec(ctx, rep, cmd)
+	if res.Err != nil {
+		return math.Int{}, res.Err
+	}
+	var resp KeyBalanceResponse
+	if err := json.Unmarshal(extractJsonResult(res.Stdout), &resp); err != nil {
+		return math.Int{}, parseError("key balance", err)
+	}
+	amount, ok := math.NewIntFromString(resp.Result.Amount)
+	if !ok {
+		return math.Int{}, parseError("key balance", fmt.Errorf("invalid amount %q", resp.Result.Amount))
+	}
+	return amount, nil
+}
+
+// parseHealthCheckOutput extracts the health of every chain reported in the JSON logs of "health-check".
+// Lines that are not JSON logs of a chain are ignored.
+func parseHealthCheckOutput(output []byte) map[string]ChainHealth {
+	health := map[string]ChainHealth{}
+	for _, line := range strings.Split(string(output), "\n") {
+		var log healthCheckLog
+		if err := json.Unmarshal([]byte(strings.TrimSpace(line)), &log); err != nil || log.Span.Chain == "" {
+			continue
+		}
+		msg := log.Fields.Message
+		h := health[log.Span.Chain]
+		switch {
+		case msg == "chain is healthy":
+			h.RPCReachable, h.GRPCReachable, h.Healthy = true, true, true
+		case strings.HasPrefix(msg, "chain is not healthy"):
+			h.Healthy = false
+			h.Problem = strings.TrimPrefix(strings.TrimPrefix(msg, "chain is not healthy"), ": ")
+			h.RPCReachable, h.GRPCReachable = endpointsReachable(h.Problem)
+		case strings.HasPrefix(msg, "failed to spawn chain runtime"), strings.HasPrefix(msg, "failed to perform health check"):
+			h.RPCReachable, h.GRPCReachable, h.Healthy = false, false, false
+			h.Problem = msg
+		default:
+			continue
+		}
+		health[log.Span.Chain] = h
+	}
+	return health
+}
+
+// endpointsReachable reports whether the RPC and gRPC endpoints of a chain were reachable, given the reason hermes
+// reported the chain as not healthy. Hermes names the interface whose check failed, e.g.
+// "health check failed for endpoint /health on the JSON RPC interface of chain gaia-1:http://gaia-1:26657/",
+// and checks the gRPC interface only once the RPC one is reachable. Any other reason, e.g. an unsynced node,
+// means both endpoints answered.
+func endpointsReachable(problem string) (rpc, grpc bool) {
+	switch {
+	case strings.Contains(problem, "on the gRPC interface"):
+		return true, false
+	case strings.Contains(problem, "RPC interface"):
+		return false, false
+	default:
+		return true, true
+	}
+}
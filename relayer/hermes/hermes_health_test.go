@@ -0,0 +1,79 @@
+package hermes
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthCheck(t *testing.T) {
+	ctx := context.Background()
+
+	health := parseHealthCheckOutput(append(readTestdata(t, "health_check.log"), readTestdata(t, "health_check.json")...))
+	require.Equal(t, map[string]ChainHealth{
+		"gaia-1":    {RPCReachable: true, GRPCReachable: true, Healthy: true},
+		"osmosis-1": {RPCReachable: true, GRPCReachable: true, Problem: "node is not synced"},
+		"juno-1":    {Problem: "failed to spawn chain runtime: rpc error"},
+		"neutron-1": {
+			RPCReachable: true,
+			Problem:      "health check failed for endpoint GetNodeInfo on the gRPC interface of chain neutron-1:http://neutron-1:9090/",
+		},
+		"stargaze-1": {
+			Problem: "health check failed for endpoint /health on the JSON RPC interface of chain stargaze-1:http://stargaze-1:26657/",
+		},
+	}, health)
+
+	checked := cannedOutput(t, "health_check.json")
+	// Hermes logs the per chain results to stderr.
+	checked.Stderr = readTestdata(t, "health_check.log")
+	f := newFakeExecutor(t, map[string][]ibc.RelayerExecResult{
+		"health-check": {checked},
+		"keys balance": {cannedOutput(t, "key_balance.json", 1000, "uatom")},
+	})
+	r := f.relayer()
+	r.chainConfigs = []ChainConfig{
+		{cfg: ibc.ChainConfig{ChainID: "gaia-1"}, keyName: "relayer"},
+		{cfg: ibc.ChainConfig{ChainID: "osmosis-1"}, keyName: "relayer"},
+		{cfg: ibc.ChainConfig{ChainID: "stride-1"}, keyName: "relayer"},
+	}
+	r.recordKey("gaia-1", "relayer")
+
+	health, err := r.HealthCheck(ctx, ibc.NopRelayerExecReporter{})
+	require.NoError(t, err)
+	require.Equal(t, map[string]ChainHealth{
+		"gaia-1":    {RPCReachable: true, GRPCReachable: true, Healthy: true, KeyPresent: true, Funded: true},
+		"osmosis-1": {RPCReachable: true, GRPCReachable: true, Problem: "node is not synced"},
+		"stride-1":  {Problem: "not reported by the health check"},
+	}, health)
+	require.True(t, health["gaia-1"].OK())
+	require.False(t, health["osmosis-1"].OK())
+}
+
+func TestAssertMinBalance(t *testing.T) {
+	ctx := context.Background()
+
+	f := newFakeExecutor(t, map[string][]ibc.RelayerExecResult{
+		"keys balance --chain gaia-1":    {cannedOutput(t, "key_balance.json", 1000, "uatom")},
+		"keys balance --chain osmosis-1": {cannedOutput(t, "key_balance.json", 250, "uosmo")},
+	})
+	r := f.relayer()
+	r.chainConfigs = []ChainConfig{
+		{cfg: ibc.ChainConfig{ChainID: "gaia-1"}, keyName: "relayer"},
+		{cfg: ibc.ChainConfig{ChainID: "osmosis-1"}, keyName: "relayer"},
+	}
+	r.SetKeyName("osmosis-1", "funded")
+
+	require.NoError(t, r.AssertMinBalance(ctx, ibc.NopRelayerExecReporter{}, map[string]sdk.Coin{"gaia-1": sdk.NewInt64Coin("uatom", 1000)}))
+	require.Equal(t, [][]string{{"keys", "balance", "--chain", "gaia-1", "--key-name", "relayer", "--denom", "uatom"}}, f.commands(""))
+
+	err := r.AssertMinBalance(ctx, ibc.NopRelayerExecReporter{}, map[string]sdk.Coin{
+		"gaia-1":    sdk.NewInt64Coin("uatom", 500),
+		"osmosis-1": sdk.NewInt64Coin("uosmo", 1000),
+		"juno-1":    sdk.NewInt64Coin("ujuno", 1),
+	})
+	require.EqualError(t, err, "relayer keys below minimum balance: chain juno-1 is not configured; "+
+		"key funded on osmosis-1 holds 250uosmo, needs 1000uosmo (short 750uosmo)")
+}
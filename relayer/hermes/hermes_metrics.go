@@ -0,0 +1,196 @@
+package hermes
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+)
+
+// relayedPacketsMetric counts the packets received on the destination chain through transactions confirmed by hermes.
+const relayedPacketsMetric = "receive_packets_confirmed_total"
+
+// metricsScript prints the response to an HTTP GET of the URL given as its first argument.
+// Not every hermes image ships curl or wget, so bash's /dev/tcp is the fallback, in which case the response
+// headers are printed too. parseMetrics skips them.
+const metricsScript = `if command -v curl >/dev/null 2>&1; then curl -sf "$1"; ` +
+	`elif command -v wget >/dev/null 2>&1; then wget -qO- "$1"; ` +
+	`else hostport=${1#http://}; hostport=${hostport%%/*}; exec 3<>/dev/tcp/${hostport%:*}/${hostport#*:} && ` +
+	`printf 'GET /metrics HTTP/1.0\r\n\r\n' >&3 && cat <&3; fi`
+
+// RelayedPacketRate returns the number of packets relayed per second by the running relayer, measured over the
+// given window from the hermes telemetry server. It requires the relayer to have been configured with
+// EnableTelemetry, or EnableLoadTestPreset, and started through StartRelayer.
+func (r *Relayer) RelayedPacketRate(ctx context.Context, rep ibc.RelayerExecReporter, window time.Duration) (float64, error) {
+	if window <= 0 {
+		return 0, fmt.Errorf("invalid window %s", window)
+	}
+	before, err := r.relayedPackets(ctx, rep)
+	if err != nil {
+		return 0, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	case <-time.After(window):
+	}
+
+	after, err := r.relayedPackets(ctx, rep)
+	if err != nil {
+		return 0, err
+	}
+	return (after - before) / window.Seconds(), nil
+}
+
+// relayedPackets returns the total number of packets relayed since the relayer was started.
+func (r *Relayer) relayedPackets(ctx context.Context, rep ibc.RelayerExecReporter) (float64, error) {
+	metrics, err := r.metrics(ctx, rep)
+	if err != nil {
+		return 0, err
+	}
+	return sumMetric(metrics, relayedPacketsMetric, nil)
+}
+
+// metrics returns the metrics of the running relayer in the prometheus text format.
+func (r *Relayer) metrics(ctx context.Context, rep ibc.RelayerExecReporter) (string, error) {
+	if !r.telemetry {
+		return "", fmt.Errorf("telemetry is not enabled, see EnableTelemetry")
+	}
+	if r.startedPaths == nil {
+		return "", fmt.Errorf("relayer has not been started")
+	}
+
+	url := fmt.Sprintf("http://%s:%d/metrics", r.HostName(strings.Join(r.startedPaths, ".")), telemetryPort)
+	res := r.Exec(ctx, rep, []string{"bash", "-c", metricsScript, "metrics", url}, nil)
+	if res.Err != nil {
+		return "", fmt.Errorf("failed to fetch metrics from %s: %w", url, res.Err)
+	}
+	return string(res.Stdout), nil
+}
+
+// RelayedPacketCount returns the number of packets sent from the given chain on the given channel that the running
+// relayer has relayed since it was started. It requires the relayer to have been configured with
+// EnableTelemetry, or EnableLoadTestPreset, and started through StartRelayer.
+func (r *Relayer) RelayedPacketCount(ctx context.Context, rep ibc.RelayerExecReporter, chainID, channelID string) (uint64, error) {
+	metrics, err := r.metrics(ctx, rep)
+	if err != nil {
+		return 0, err
+	}
+	count, err := sumMetric(metrics, relayedPacketsMetric, map[string]string{"chain": chainID, "channel": channelID})
+	if err != nil {
+		return 0, err
+	}
+	return uint64(count), nil
+}
+
+// MetricSample holds the metrics of the relayer scraped at a point in time.
+type MetricSample struct {
+	Time time.Time
+	// Values contains a mapping of sample, the metric name and its labels as exposed by the telemetry server,
+	// e.g. `receive_packets_confirmed_total{chain="gaia-1",channel="channel-0",port="transfer"}`, to its value.
+	Values map[string]float64
+}
+
+// Sum sums the values of the named metric across all of its samples whose labels include the given ones.
+func (s MetricSample) Sum(name string, labels map[string]string) float64 {
+	var sum float64
+	for sample, v := range s.Values {
+		sampleName, sampleLabels, _ := strings.Cut(sample, "{")
+		if sampleName == name && hasLabels(sampleLabels, labels) {
+			sum += v
+		}
+	}
+	return sum
+}
+
+// CollectMetrics scrapes the hermes telemetry server every interval for the given duration, starting immediately,
+// and returns the samples in the order they were taken, e.g. to compare the throughput of runs.
+// It requires the relayer to have been configured with EnableTelemetry, or EnableLoadTestPreset, and started
+// through StartRelayer. If a scrape fails or ctx is done, the samples taken so far are returned along with the error.
+func (r *Relayer) CollectMetrics(ctx context.Context, rep ibc.RelayerExecReporter, interval, duration time.Duration) ([]MetricSample, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("invalid interval %s", interval)
+	}
+	deadline := time.Now().Add(duration)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var samples []MetricSample
+	for {
+		metrics, err := r.metrics(ctx, rep)
+		if err != nil {
+			return samples, err
+		}
+		values, err := parseMetrics(metrics)
+		if err != nil {
+			return samples, err
+		}
+		samples = append(samples, MetricSample{Time: time.Now(), Values: values})
+
+		select {
+		case <-ctx.Done():
+			return samples, ctx.Err()
+		case now := <-ticker.C:
+			if now.After(deadline) {
+				return samples, nil
+			}
+		}
+	}
+}
+
+// parseMetrics returns the value of every sample in the prometheus text format, keyed by the metric name and labels.
+// The response headers printed by the /dev/tcp fallback of metricsScript are skipped.
+func parseMetrics(metrics string) (map[string]float64, error) {
+	if strings.HasPrefix(metrics, "HTTP/") {
+		_, metrics, _ = strings.Cut(metrics, "\r\n\r\n")
+	}
+	values := map[string]float64{}
+	for _, line := range strings.Split(metrics, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		v, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return nil, parseError("metric "+fields[0], err)
+		}
+		values[fields[0]] = v
+	}
+	return values, nil
+}
+
+// sumMetric sums the samples of the named metric in the prometheus text format, across all of its samples whose
+// labels include the given ones. A metric without matching samples, e.g. a counter that was never incremented,
+// sums to zero.
+func sumMetric(metrics, name string, labels map[string]string) (float64, error) {
+	values, err := parseMetrics(metrics)
+	if err != nil {
+		return 0, err
+	}
+	return MetricSample{Values: values}.Sum(name, labels), nil
+}
+
+// hasLabels reports whether the labels of a sample, e.g. `chain="gaia-1",channel="channel-0"}`, include the given ones.
+func hasLabels(sampleLabels string, labels map[string]string) bool {
+	values := map[string]string{}
+	for _, label := range strings.Split(strings.TrimSuffix(sampleLabels, "}"), ",") {
+		if k, v, ok := strings.Cut(label, "="); ok {
+			values[k] = strings.Trim(v, `"`)
+		}
+	}
+	for k, v := range labels {
+		if values[k] != v {
+			return false
+		}
+	}
+	return true
+}
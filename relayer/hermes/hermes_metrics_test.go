@@ -0,0 +1,97 @@
+package hermes
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelayedPacketRate(t *testing.T) {
+	ctx := context.Background()
+
+	f := newFakeExecutor(t, map[string][]ibc.RelayerExecResult{
+		"bash": {cannedOutput(t, "metrics.txt", 10), cannedOutput(t, "metrics.txt", 30)},
+	})
+	r := f.relayer()
+
+	_, err := r.RelayedPacketRate(ctx, ibc.NopRelayerExecReporter{}, 100*time.Millisecond)
+	require.ErrorContains(t, err, "telemetry is not enabled")
+
+	r.EnableTelemetry()
+	_, err = r.RelayedPacketRate(ctx, ibc.NopRelayerExecReporter{}, 100*time.Millisecond)
+	require.ErrorContains(t, err, "relayer has not been started")
+
+	r.startedPaths = []string{"p"}
+	rate, err := r.RelayedPacketRate(ctx, ibc.NopRelayerExecReporter{}, 100*time.Millisecond)
+	require.NoError(t, err)
+	require.InDelta(t, 200, rate, 0.001)
+	require.Len(t, f.cmds, 2)
+	require.Equal(t, fmt.Sprintf("http://%s:3001/metrics", r.HostName("p")), f.cmds[0][len(f.cmds[0])-1])
+}
+
+func TestRelayedPacketCount(t *testing.T) {
+	ctx := context.Background()
+
+	f := newFakeExecutor(t, map[string][]ibc.RelayerExecResult{
+		"bash": {cannedOutput(t, "metrics.txt", 0)},
+	})
+	r := f.relayer()
+	r.EnableLoadTestPreset()
+	r.startedPaths = []string{"p"}
+
+	count, err := r.RelayedPacketCount(ctx, ibc.NopRelayerExecReporter{}, "osmosis-1", "channel-0")
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	// Relaying three transfers increments the count of the channel only.
+	f.respond("bash", cannedOutput(t, "metrics.txt", 3))
+	count, err = r.RelayedPacketCount(ctx, ibc.NopRelayerExecReporter{}, "osmosis-1", "channel-0")
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), count)
+
+	count, err = r.RelayedPacketCount(ctx, ibc.NopRelayerExecReporter{}, "gaia-1", "channel-0")
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), count)
+
+	count, err = r.RelayedPacketCount(ctx, ibc.NopRelayerExecReporter{}, "juno-1", "channel-0")
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
+
+func TestCollectMetrics(t *testing.T) {
+	ctx := context.Background()
+
+	// The response headers are printed when the metrics are fetched through bash's /dev/tcp.
+	const headers = "HTTP/1.0 200 OK\r\ncontent-type: text/plain; version=0.0.4\r\n\r\n"
+	var scrapes []ibc.RelayerExecResult
+	for total := 10; total <= 100; total += 10 {
+		scrapes = append(scrapes, ibc.RelayerExecResult{Stdout: append([]byte(headers), readTestdata(t, "metrics.txt", total)...)})
+	}
+	f := newFakeExecutor(t, map[string][]ibc.RelayerExecResult{"bash": scrapes})
+	r := f.relayer()
+
+	_, err := r.CollectMetrics(ctx, ibc.NopRelayerExecReporter{}, 10*time.Millisecond, 50*time.Millisecond)
+	require.ErrorContains(t, err, "telemetry is not enabled")
+
+	r.EnableTelemetry()
+	r.startedPaths = []string{"p"}
+	samples, err := r.CollectMetrics(ctx, ibc.NopRelayerExecReporter{}, 20*time.Millisecond, 90*time.Millisecond)
+	require.NoError(t, err)
+	require.Len(t, samples, 5)
+	for i, sample := range samples {
+		require.Equal(t, float64((i+1)*10), sample.Values[`receive_packets_confirmed_total{chain="osmosis-1",channel="channel-0",port="transfer"}`])
+		require.Equal(t, float64((i+1)*10+5), sample.Sum(relayedPacketsMetric, nil))
+		if i > 0 {
+			require.True(t, sample.Time.After(samples[i-1].Time))
+		}
+	}
+
+	// The packet rate and count are summed from the same parsed samples, with the response headers skipped.
+	sum, err := sumMetric(string(scrapes[0].Stdout), relayedPacketsMetric, map[string]string{"chain": "gaia-1"})
+	require.NoError(t, err)
+	require.Equal(t, float64(5), sum)
+}
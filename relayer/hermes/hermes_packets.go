@@ -0,0 +1,184 @@
+package hermes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+)
+
+// PacketStage is the relayer's view of whether a packet has reached a stage of its lifecycle.
+// Height and ObservedAt are when the relayer's query observed the stage, not when the stage was reached.
+type PacketStage struct {
+	Done       bool
+	Height     uint64
+	ObservedAt time.Time
+}
+
+// PacketLifecycle is the relayer's view of the lifecycle of a packet sent on a channel.
+type PacketLifecycle struct {
+	Sequence uint64
+
+	// Committed reports whether the packet was committed on the source chain.
+	Committed PacketStage
+	// Received reports whether the packet was received on the destination chain.
+	Received PacketStage
+	// Acknowledged reports whether the acknowledgement of the packet was relayed back to the source chain,
+	// which removes the packet commitment.
+	Acknowledged PacketStage
+}
+
+// PacketLifecycle queries both ends of the given channel on the source chain of the path for the stages
+// the packet with the given sequence has reached.
+//
+// The stages are derived from the packet commitments on the source chain, and from the packets not yet received
+// and the acknowledgements written on the destination chain.
+func (r *Relayer) PacketLifecycle(ctx context.Context, rep ibc.RelayerExecReporter, pathName, channelID string, sequence uint64) (PacketLifecycle, error) {
+	path, err := r.path(pathName)
+	if err != nil {
+		return PacketLifecycle{}, err
+	}
+	src := path.chainA
+	ch, err := ibc.GetChannel(ctx, r, rep, src.chainID, channelID)
+	if err != nil {
+		return PacketLifecycle{}, err
+	}
+	dstChainID, dstPort, dstChannel := path.chainB.chainID, ch.Counterparty.PortID, ch.Counterparty.ChannelID
+
+	var commitments PacketSequencesResponse
+	if err := r.queryPackets(ctx, rep, "commitments", src.chainID, ch.PortID, channelID, &commitments); err != nil {
+		return PacketLifecycle{}, err
+	}
+	srcObservedAt := time.Now()
+	var unreceived UnreceivedPacketsResponse
+	if err := r.queryPackets(ctx, rep, "unreceived-packets", dstChainID, dstPort, dstChannel, &unreceived); err != nil {
+		return PacketLifecycle{}, err
+	}
+	var acks PacketSequencesResponse
+	if err := r.queryPackets(ctx, rep, "acks", dstChainID, dstPort, dstChannel, &acks); err != nil {
+		return PacketLifecycle{}, err
+	}
+	dstObservedAt := time.Now()
+
+	committed := slices.Contains(commitments.Result.Seqs, sequence)
+	acked := slices.Contains(acks.Result.Seqs, sequence)
+	srcHeight, dstHeight := commitments.Result.Height.RevisionHeight, acks.Result.Height.RevisionHeight
+
+	lifecycle := PacketLifecycle{Sequence: sequence}
+	if committed || acked {
+		lifecycle.Committed = PacketStage{Done: true, Height: srcHeight, ObservedAt: srcObservedAt}
+	}
+	if (committed && !slices.Contains(unreceived.Result, sequence)) || acked {
+		lifecycle.Received = PacketStage{Done: true, Height: dstHeight, ObservedAt: dstObservedAt}
+	}
+	if acked && !committed {
+		lifecycle.Acknowledged = PacketStage{Done: true, Height: srcHeight, ObservedAt: srcObservedAt}
+	}
+	return lifecycle, nil
+}
+
+// packetReceivedPollInterval is how often WaitForPacketReceived queries the destination channel end.
+const packetReceivedPollInterval = time.Second
+
+// WaitForPacketReceived waits until the packet with the given sequence has been received on the given channel end
+// of the destination chain, timeout elapses, or ctx is done.
+//
+// The packet is received once it is no longer reported by the unreceived-packets query and its acknowledgement
+// has been written on the destination chain, so packets acknowledged asynchronously are not detected.
+// On timeout the error includes the last observed state of the packet.
+func (r *Relayer) WaitForPacketReceived(ctx context.Context, rep ibc.RelayerExecReporter, dstChainID, portID, channelID string, sequence uint64, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		var unreceived UnreceivedPacketsResponse
+		if err := r.queryPackets(ctx, rep, "unreceived-packets", dstChainID, portID, channelID, &unreceived); err != nil {
+			return err
+		}
+		state := "committed on the counterparty but not received"
+		if !slices.Contains(unreceived.Result, sequence) {
+			var acks PacketSequencesResponse
+			if err := r.queryPackets(ctx, rep, "acks", dstChainID, portID, channelID, &acks); err != nil {
+				return err
+			}
+			if slices.Contains(acks.Result.Seqs, sequence) {
+				return nil
+			}
+			// The packet is either not committed on the counterparty, e.g. because it was not sent yet or timed out,
+			// or it was received and its acknowledgement is written asynchronously. Hermes cannot query the packet
+			// receipt that tells these apart.
+			state = "not pending receipt and no acknowledgement written"
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("packet %d not received on %s/%s of %s after %s, last state: %s: %w",
+				sequence, portID, channelID, dstChainID, timeout, state, ctx.Err())
+		case <-time.After(packetReceivedPollInterval):
+		}
+	}
+}
+
+// queryPackets runs the "query packet" subcommand of the given kind against a channel end
+// and decodes its JSON result into resp.
+func (r *Relayer) queryPackets(ctx context.Context, rep ibc.RelayerExecReporter, kind, chainID, portID, channelID string, resp any) error {
+	cmd := r.c.hermesCmd(r.HomeDir(), "--json", "query", "packet", kind, "--chain", chainID, "--port", portID, "--channel", channelID)
+	res := r.exec(ctx, rep, cmd)
+	if res.Err != nil {
+		return res.Err
+	}
+	if err := json.Unmarshal(extractJsonResult(res.Stdout), resp); err != nil {
+		return parseError(fmt.Sprintf("packet %s", kind), err)
+	}
+	return nil
+}
+
+// PendingSummary counts the packets and acknowledgements pending on a channel end, in both directions.
+type PendingSummary struct {
+	PortID string
+
+	// UnreceivedPackets counts the packets sent from the chain that the counterparty has not received,
+	// and UnreceivedAcks the acknowledgements of packets sent from the chain that have not been relayed back.
+	UnreceivedPackets int
+	UnreceivedAcks    int
+
+	// CounterpartyUnreceivedPackets and CounterpartyUnreceivedAcks count the same for packets sent from the counterparty.
+	CounterpartyUnreceivedPackets int
+	CounterpartyUnreceivedAcks    int
+}
+
+// Total returns the number of packets and acknowledgements pending on the channel end.
+func (s PendingSummary) Total() int {
+	return s.UnreceivedPackets + s.UnreceivedAcks + s.CounterpartyUnreceivedPackets + s.CounterpartyUnreceivedAcks
+}
+
+// GetAllPendingPackets summarizes the packets and acknowledgements pending on every open channel of the given chain,
+// keyed by channel ID, e.g. to check that all traffic has been relayed before ending a test.
+func (r *Relayer) GetAllPendingPackets(ctx context.Context, rep ibc.RelayerExecReporter, chainID string) (map[string]PendingSummary, error) {
+	channels, err := r.GetChannels(ctx, rep, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get channels on %s: %w", chainID, err)
+	}
+
+	summaries := map[string]PendingSummary{}
+	for _, ch := range channels {
+		if !ch.IsOpen() {
+			continue
+		}
+		var resp PendingPacketsResponse
+		if err := r.queryPackets(ctx, rep, "pending", chainID, ch.PortID, ch.ChannelID, &resp); err != nil {
+			return nil, fmt.Errorf("failed to query pending packets on %s/%s: %w", ch.PortID, ch.ChannelID, err)
+		}
+		summaries[ch.ChannelID] = PendingSummary{
+			PortID:                        ch.PortID,
+			UnreceivedPackets:             len(resp.Result.Src.UnreceivedPackets),
+			UnreceivedAcks:                len(resp.Result.Src.UnreceivedAcks),
+			CounterpartyUnreceivedPackets: len(resp.Result.Dst.UnreceivedPackets),
+			CounterpartyUnreceivedAcks:    len(resp.Result.Dst.UnreceivedAcks),
+		}
+	}
+	return summaries, nil
+}
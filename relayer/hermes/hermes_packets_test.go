@@ -0,0 +1,105 @@
+package hermes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPacketLifecycle(t *testing.T) {
+	ctx := context.Background()
+
+	f := newFakeExecutor(t, map[string][]ibc.RelayerExecResult{
+		"query channels":                  {cannedOutput(t, "channels_transfer.json")},
+		"query packet commitments":        {cannedOutput(t, "packet_commitments.json")},
+		"query packet unreceived-packets": {cannedOutput(t, "packet_unreceived.json", 4)},
+		"query packet acks":               {cannedOutput(t, "packet_acks.json")},
+	})
+	r := f.relayer()
+	require.NoError(t, r.GeneratePath(ctx, ibc.NopRelayerExecReporter{}, "gaia-1", "osmosis-1", "p"))
+
+	lifecycle := func(seq uint64) PacketLifecycle {
+		l, err := r.PacketLifecycle(ctx, ibc.NopRelayerExecReporter{}, "p", "channel-0", seq)
+		require.NoError(t, err)
+		return l
+	}
+
+	acked := lifecycle(2)
+	require.Equal(t, uint64(2), acked.Sequence)
+	require.True(t, acked.Committed.Done)
+	require.Equal(t, uint64(120), acked.Committed.Height)
+	require.False(t, acked.Committed.ObservedAt.IsZero())
+	require.True(t, acked.Received.Done)
+	require.Equal(t, uint64(80), acked.Received.Height)
+	require.True(t, acked.Acknowledged.Done)
+	require.Equal(t, uint64(120), acked.Acknowledged.Height)
+
+	received := lifecycle(3)
+	require.True(t, received.Committed.Done)
+	require.True(t, received.Received.Done)
+	require.False(t, received.Acknowledged.Done)
+
+	committed := lifecycle(4)
+	require.True(t, committed.Committed.Done)
+	require.False(t, committed.Received.Done)
+	require.False(t, committed.Acknowledged.Done)
+
+	unsent := lifecycle(5)
+	require.Equal(t, PacketLifecycle{Sequence: 5}, unsent)
+
+	cmds := f.commands("query packet")
+	require.Contains(t, cmds, []string{"query", "packet", "commitments", "--chain", "gaia-1", "--port", "transfer", "--channel", "channel-0"})
+	require.Contains(t, cmds, []string{"query", "packet", "unreceived-packets", "--chain", "osmosis-1", "--port", "transfer", "--channel", "channel-9"})
+	require.Contains(t, cmds, []string{"query", "packet", "acks", "--chain", "osmosis-1", "--port", "transfer", "--channel", "channel-9"})
+
+	_, err := r.PacketLifecycle(ctx, ibc.NopRelayerExecReporter{}, "p", "channel-7", 1)
+	require.ErrorIs(t, err, ibc.ErrChannelNotFound)
+}
+
+func TestGetAllPendingPackets(t *testing.T) {
+	ctx := context.Background()
+
+	f := newFakeExecutor(t, map[string][]ibc.RelayerExecResult{
+		"query channels":       {cannedOutput(t, "channels_mixed_states.json")},
+		"query packet pending": {cannedOutput(t, "packet_pending_none.json")},
+		"query packet pending --chain gaia-1 --port transfer --channel channel-0": {cannedOutput(t, "packet_pending.json")},
+	})
+	r := f.relayer()
+
+	summaries, err := r.GetAllPendingPackets(ctx, ibc.NopRelayerExecReporter{}, "gaia-1")
+	require.NoError(t, err)
+	require.Equal(t, map[string]PendingSummary{
+		"channel-0": {PortID: "transfer", UnreceivedPackets: 1, UnreceivedAcks: 3, CounterpartyUnreceivedPackets: 2, CounterpartyUnreceivedAcks: 1},
+		"channel-1": {PortID: "icahost"},
+	}, summaries)
+	require.Equal(t, 7, summaries["channel-0"].Total())
+	require.Zero(t, summaries["channel-1"].Total())
+	require.Equal(t, [][]string{
+		{"query", "packet", "pending", "--chain", "gaia-1", "--port", "transfer", "--channel", "channel-0"},
+		{"query", "packet", "pending", "--chain", "gaia-1", "--port", "icahost", "--channel", "channel-1"},
+	}, f.commands("query packet pending"))
+}
+
+func TestWaitForPacketReceived(t *testing.T) {
+	ctx := context.Background()
+
+	// The packet is received after the first poll.
+	f := newFakeExecutor(t, map[string][]ibc.RelayerExecResult{
+		"query packet unreceived-packets": {cannedOutput(t, "packet_unreceived.json", 3), cannedOutput(t, "empty_list.json")},
+		"query packet acks":               {cannedOutput(t, "packet_acks.json")},
+	})
+	r := f.relayer()
+
+	require.NoError(t, r.WaitForPacketReceived(ctx, ibc.NopRelayerExecReporter{}, "osmosis-1", "transfer", "channel-9", 3, time.Minute))
+	require.Len(t, f.commands("query packet unreceived-packets"), 2)
+	for _, cmd := range f.commands("") {
+		require.Equal(t, []string{"--chain", "osmosis-1", "--port", "transfer", "--channel", "channel-9"}, cmd[3:])
+	}
+
+	err := r.WaitForPacketReceived(ctx, ibc.NopRelayerExecReporter{}, "osmosis-1", "transfer", "channel-9", 6, 100*time.Millisecond)
+	require.ErrorContains(t, err, "packet 6 not received on transfer/channel-9 of osmosis-1 after 100ms, last state: not pending receipt and no acknowledgement written")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
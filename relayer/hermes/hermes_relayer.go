@@ -1,17 +1,27 @@
 package hermes
 
 import (
+	"bytes"
 	"context"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
 	"regexp"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"text/template"
 	"time"
 
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
 	"github.com/docker/docker/client"
 	"github.com/pelletier/go-toml"
 	"github.com/strangelove-ventures/interchaintest/v8/ibc"
 	"github.com/strangelove-ventures/interchaintest/v8/relayer"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 )
 
@@ -23,20 +33,67 @@ const (
 	hermesDefaultUidGid = "1001:1001"
 	hermesHome          = "/home/hermes"
 	hermesConfigPath    = ".hermes/config.toml"
+	hermesKeysPath      = ".hermes/keys"
+
+	// tendermintClientType is the type of the clients hermes creates for cosmos chains.
+	tendermintClientType = "07-tendermint"
+
+	// defaultMaxBlockTime is the max block time of every chain in the generated config, unless overridden.
+	defaultMaxBlockTime = 30 * time.Second
 )
 
 var (
-	_ ibc.Relayer = &Relayer{}
+	_ ibc.Relayer                = &Relayer{}
+	_ ibc.TransferOptionsChecker = &Relayer{}
 	// parseRestoreKeyOutputPattern extracts the address from the hermes output.
 	// SUCCESS Restored key 'g2-2' (cosmos1czklnpzwaq3hfxtv6ne4vas2p9m5q3p3fgkz8e) on chain g2-2
 	parseRestoreKeyOutputPattern = regexp.MustCompile(`\((.*)\)`)
+	// parseVersionOutputPattern extracts the version from the hermes output.
+	// hermes 1.6.0+4b5b34e
+	parseVersionOutputPattern = regexp.MustCompile(`(?m)^hermes\s+v?(\S+)\s*$`)
 )
 
 // Relayer is the ibc.Relayer implementation for hermes.
 type Relayer struct {
 	*relayer.DockerRelayer
+	c            *commander
 	paths        map[string]*pathConfiguration
 	chainConfigs []ChainConfig
+
+	// configOverride, when set, is written as the hermes config file instead of a generated one.
+	configOverride []byte
+
+	// keys contains a mapping of chainID to the names of keys restored for that chain.
+	keys map[string]map[string]struct{}
+
+	// chainSettings contains a mapping of chainID to overrides of the generated chain config.
+	chainSettings map[string]*chainSettings
+
+	// rpcTimeout and maxBlockTime, when non-zero, override the hermes defaults for every chain.
+	rpcTimeout   time.Duration
+	maxBlockTime time.Duration
+
+	// packetLogging raises the hermes log level so that full packet data is logged.
+	packetLogging bool
+
+	// loadTest applies the settings of EnableLoadTestPreset to the generated config.
+	loadTest bool
+
+	// telemetry enables the hermes telemetry server in the generated config.
+	telemetry bool
+
+	// txConfirmation, when set, overrides whether hermes waits for relayed transactions to be confirmed.
+	txConfirmation *bool
+
+	// startedPaths are the paths passed to the last successful StartRelayer call.
+	startedPaths []string
+
+	// droppedSequences are the sequences of the packets that Flush and RelayOnce do not relay.
+	droppedSequences []uint64
+
+	// handshakeAttempts and handshakeBackoff, when non-zero, override the retries of handshake commands.
+	handshakeAttempts int
+	handshakeBackoff  time.Duration
 }
 
 // ChainConfig holds all values required to write an entry in the "chains" section in the hermes config file.
@@ -61,7 +118,7 @@ type pathChainConfig struct {
 
 // NewHermesRelayer returns a new hermes relayer.
 func NewHermesRelayer(log *zap.Logger, testName string, cli *client.Client, networkID string, options ...relayer.RelayerOpt) *Relayer {
-	c := commander{log: log}
+	c := &commander{log: log}
 
 	options = append(options, relayer.HomeDir(hermesHome))
 	dr, err := relayer.NewDockerRelayer(context.TODO(), log, testName, cli, networkID, c, options...)
@@ -69,33 +126,380 @@ func NewHermesRelayer(log *zap.Logger, testName string, cli *client.Client, netw
 		panic(err)
 	}
 	c.extraStartFlags = dr.GetExtraStartupFlags()
+	c.layout = layoutForVersion(dr.ContainerImage().Version)
 
 	return &Relayer{
 		DockerRelayer: dr,
+		c:             c,
 	}
 }
 
+// NewHermesRelayerWithExecutor returns a hermes relayer that passes every hermes command to exec instead of running
+// it in docker, so that command generation and output parsing can be tested without docker.
+// See relayer.NewDockerRelayerWithExecutor for the limitations of such a relayer.
+func NewHermesRelayerWithExecutor(log *zap.Logger, testName string, exec relayer.Executor, options ...relayer.RelayerOpt) *Relayer {
+	c := &commander{log: log}
+
+	options = append(options, relayer.HomeDir(hermesHome))
+	dr := relayer.NewDockerRelayerWithExecutor(log, testName, c, exec, options...)
+	c.extraStartFlags = dr.GetExtraStartupFlags()
+	c.layout = layoutForVersion(dr.ContainerImage().Version)
+
+	return &Relayer{
+		DockerRelayer: dr,
+		c:             c,
+	}
+}
+
+// KeyringDir mounts the host directory hostDir as the hermes keyring, so that the keys added through AddKey and
+// RestoreKey are written to it. Relayers created with the same directory share their keys, e.g. a key restored by
+// one of them is listed by ListKeys on the others. Only the keys restored by a relayer itself are reported by its
+// HasKey, so each relayer should still restore the keys it selects with SetKey.
+//
+// The directory must exist and be writable by the user of the hermes image, uid 1001.
+func KeyringDir(hostDir string) relayer.RelayerOpt {
+	return relayer.HostMount(hostDir, fmt.Sprintf("%s/%s", hermesHome, hermesKeysPath), false)
+}
+
+// Capabilities returns the set of capabilities of the hermes relayer.
+//
+// Timeouts by timestamp are not reliably relayed by hermes in interchaintest,
+// so the TimestampTimeout capability is reported as unsupported.
+func Capabilities() map[relayer.Capability]bool {
+	caps := relayer.FullCapabilities()
+	caps[relayer.TimestampTimeout] = false
+	return caps
+}
+
+// Capability reports whether this relayer supports the given capability.
+func (r *Relayer) Capability(c relayer.Capability) bool {
+	return Capabilities()[c]
+}
+
+// CheckTransferOptions returns an error wrapping relayer.ErrUnsupportedCapability
+// if a transfer sent with opts requires a timeout that hermes does not support relaying.
+func (r *Relayer) CheckTransferOptions(opts ibc.TransferOptions) error {
+	return relayer.CheckTransferOptions(Capabilities(), opts)
+}
+
+// SetConfigPath overrides the location of the hermes config file, relative to the relayer home directory.
+// It defaults to the location expected by the hermes version of the relayer image, see ConfigPath,
+// and must be set before any chains are added.
+func (r *Relayer) SetConfigPath(relativePath string) {
+	r.c.configPath = relativePath
+}
+
+// ConfigPath returns the location of the hermes config file, relative to the relayer home directory,
+// that every command is pointed at. This is ".hermes/config.toml", or "config.toml" for hermes versions before v0.7.0.
+func (r *Relayer) ConfigPath() string {
+	return r.c.relativeConfigPath()
+}
+
+// SetBinary overrides the hermes executable run by every command, e.g. "/usr/local/bin/hermes-patched"
+// for a patched image that does not provide hermes on its PATH. It defaults to "hermes".
+func (r *Relayer) SetBinary(binary string) {
+	r.c.binary = binary
+}
+
+// LogFormat is the format of the logs of the running relayer.
+type LogFormat string
+
+const (
+	// LogFormatDefault keeps the format of the image, which is plain for the official hermes images.
+	LogFormatDefault LogFormat = ""
+	// LogFormatPlain logs human-readable lines.
+	LogFormatPlain LogFormat = "plain"
+	// LogFormatJSON logs a JSON object per line, which is easier to scrape.
+	LogFormatJSON LogFormat = "json"
+)
+
+// SetLogFormat sets the format of the logs of the relayer started through StartRelayer.
+// Hermes has no config setting for its log format, so JSON logs are enabled through the global --json flag
+// of "hermes start".
+func (r *Relayer) SetLogFormat(format LogFormat) error {
+	switch format {
+	case LogFormatDefault, LogFormatPlain, LogFormatJSON:
+		r.c.logFormat = format
+		return nil
+	default:
+		return fmt.Errorf("invalid log format %q", format)
+	}
+}
+
+// SetExtraStartFlags replaces the flags appended to "hermes start", e.g. "--full-scan".
+// These default to the flags passed through the relayer.StartupFlags option.
+// The config file flag is managed by the relayer and may not be passed.
+func (r *Relayer) SetExtraStartFlags(flags ...string) error {
+	if err := validateStartFlags(flags); err != nil {
+		return err
+	}
+	r.c.extraStartFlags = flags
+	return nil
+}
+
+// validateStartFlags rejects extra start flags that conflict with the global flags the relayer passes to hermes.
+func validateStartFlags(flags []string) error {
+	for _, f := range flags {
+		if f == "--config" || strings.HasPrefix(f, "--config=") {
+			return fmt.Errorf("invalid extra start flag %q: the config file is set by the relayer, use SetConfigPath instead", f)
+		}
+	}
+	return nil
+}
+
+// SetConfigOverride configures the relayer to use the provided hermes config file verbatim
+// rather than generating one from the chain configurations passed to AddChainConfiguration.
+//
+// The content is treated as a text/template, so the addresses of the chains added through
+// AddChainConfiguration may be substituted with {{ rpc "<chain-id>" }} and {{ grpc "<chain-id>" }}.
+func (r *Relayer) SetConfigOverride(content []byte) {
+	r.configOverride = content
+}
+
+// SetConfigOverrideFromFile reads the hermes config file at the given path on the host
+// and uses it as described in SetConfigOverride.
+func (r *Relayer) SetConfigOverrideFromFile(path string) error {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read hermes config override: %w", err)
+	}
+	r.SetConfigOverride(bz)
+	return nil
+}
+
+// SetFeeGranter configures the address that grants fees to the relayer key for transactions submitted on the given chain.
+// It must be called before the chain is added through AddChainConfiguration.
+func (r *Relayer) SetFeeGranter(chainID, granter string) {
+	r.settingsFor(chainID).feeGranter = granter
+}
+
+// SetMemoPrefix overrides the memo that hermes attaches to transactions submitted on the given chain.
+// It must be called before the chain is added through AddChainConfiguration.
+func (r *Relayer) SetMemoPrefix(chainID, memo string) {
+	r.settingsFor(chainID).memoPrefix = &memo
+}
+
+// SetDirectionalMemos sets distinct memos on the transactions hermes submits when relaying between two chains:
+// transactions relaying from srcChainID to dstChainID, i.e. received packets and acknowledgements submitted on
+// dstChainID, carry srcToDst, and transactions relaying the other way carry dstToSrc.
+// Hermes attaches memos per chain, so a chain relayed to from several chains uses the same memo for all of them.
+// An empty memo leaves the memo of that direction unchanged.
+// It must be called before the chains are added through AddChainConfiguration.
+func (r *Relayer) SetDirectionalMemos(srcChainID, dstChainID, srcToDst, dstToSrc string) error {
+	if srcChainID == dstChainID {
+		return fmt.Errorf("cannot set directional memos between chain %s and itself", srcChainID)
+	}
+	if srcToDst != "" {
+		r.SetMemoPrefix(dstChainID, srcToDst)
+	}
+	if dstToSrc != "" {
+		r.SetMemoPrefix(srcChainID, dstToSrc)
+	}
+	return nil
+}
+
+// SetTrustedNode marks the full node of the given chain as trusted, so hermes skips light client verification of
+// the headers it receives from it. This speeds up relaying at the expense of safety; by default, headers are verified.
+// It must be called before the chain is added through AddChainConfiguration.
+func (r *Relayer) SetTrustedNode(chainID string, trusted bool) {
+	r.settingsFor(chainID).trustedNode = trusted
+}
+
+// SetClockDrift overrides the maximum clock drift tolerated between the given chain and the chains it is connected to,
+// which defaults to 5s. Clients created by hermes for the chain allow for this drift plus the max block time of the
+// counterparty, so tests running chains with skewed clocks can raise it to avoid headers being rejected as in the future.
+// It must be called before the chain is added through AddChainConfiguration.
+func (r *Relayer) SetClockDrift(chainID string, drift time.Duration) {
+	r.settingsFor(chainID).clockDrift = drift
+}
+
+// SetKeyName selects the key hermes signs transactions on the given chain with, in place of the key name passed to
+// AddChainConfiguration. This allows restoring several keys for a chain through RestoreKey and choosing between them.
+// The key must have been restored by the time the relayer is started.
+func (r *Relayer) SetKeyName(chainID, keyName string) {
+	r.settingsFor(chainID).keyName = keyName
+}
+
+// SetGasMultiplier overrides the factor hermes multiplies the simulated gas of transactions on the given chain by,
+// which defaults to the gas adjustment of the chain config, or 1.1 if it has none. Raising it gives transactions
+// more headroom, so that they do not run out of gas when the state changes between simulation and execution.
+// It must be called before the chain is added through AddChainConfiguration.
+func (r *Relayer) SetGasMultiplier(chainID string, multiplier float64) {
+	r.settingsFor(chainID).gasMultiplier = multiplier
+}
+
+// SetGasLimits overrides the gas hermes uses for transactions on the given chain when their simulation fails,
+// which defaults to 100000, and the maximum gas of any transaction after applying the gas multiplier,
+// which defaults to 400000. A zero value keeps the corresponding default.
+// It must be called before the chain is added through AddChainConfiguration.
+func (r *Relayer) SetGasLimits(chainID string, defaultGas, maxGas int) {
+	settings := r.settingsFor(chainID)
+	settings.defaultGas = defaultGas
+	settings.maxGas = maxGas
+}
+
+// SetAddressType overrides how hermes derives the addresses of keys on the given chain, which defaults to the cosmos
+// derivation. Chains whose keys are derived differently, e.g. with coin type 60, need EthermintAddressType.
+// It must be called before the chain is added through AddChainConfiguration.
+func (r *Relayer) SetAddressType(chainID string, addressType AddressType) {
+	r.settingsFor(chainID).addressType = &addressType
+}
+
+// SetRPCTimeout overrides how long hermes waits for responses to RPC queries and transactions on every chain,
+// which defaults to 10s. Slow chains may need longer to avoid timeouts during handshakes.
+// It must be called before any chains are added through AddChainConfiguration.
+func (r *Relayer) SetRPCTimeout(timeout time.Duration) {
+	r.rpcTimeout = timeout
+}
+
+// SetMaxBlockTime overrides the maximum time hermes expects any chain to take to produce a block, which defaults to 30s.
+// It must be called before any chains are added through AddChainConfiguration.
+func (r *Relayer) SetMaxBlockTime(maxBlockTime time.Duration) {
+	r.maxBlockTime = maxBlockTime
+}
+
+// EnablePacketLogging configures hermes to log at trace level, which includes the full data of every relayed packet.
+// This is verbose and intended for debugging relay failures together with PacketLogs.
+// It must be called before any chains are added through AddChainConfiguration.
+func (r *Relayer) EnablePacketLogging() {
+	r.packetLogging = true
+}
+
+// EnableLoadTestPreset configures hermes for throughput benchmarks: packets are batched into transactions of up to
+// loadTestMaxMsgNum messages with a matching gas limit, events are batched with a shorter delay, periodic packet
+// clearing is disabled in favour of relaying on events, and transactions are confirmed so that the telemetry
+// server, which is enabled, reports relayed packets. See RelayedPacketRate.
+// It must be called before any chains are added through AddChainConfiguration.
+func (r *Relayer) EnableLoadTestPreset() {
+	r.loadTest = true
+	r.telemetry = true
+}
+
+// EnableTelemetry enables the hermes telemetry server, from which RelayedPacketRate and RelayedPacketCount
+// read the metrics of the running relayer, without changing how hermes relays packets.
+// It must be called before any chains are added through AddChainConfiguration.
+func (r *Relayer) EnableTelemetry() {
+	r.telemetry = true
+}
+
+// SetTxConfirmation sets whether hermes waits for its packet transactions to be included in a block before
+// reporting them as relayed. Hermes does not wait by default, which is faster but means failed transactions
+// are only noticed when the packets are cleared again. This takes precedence over EnableLoadTestPreset.
+// It must be called before any chains are added through AddChainConfiguration.
+func (r *Relayer) SetTxConfirmation(enabled bool) {
+	r.txConfirmation = &enabled
+}
+
+// PacketLogs returns the lines of the running relayer's logs that mention the packet with the given sequence,
+// tracing its lifecycle from being sent through to its acknowledgement or timeout.
+func (r *Relayer) PacketLogs(ctx context.Context, sequence uint64) ([]string, error) {
+	logs, err := r.Logs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return packetLogLines(logs, sequence), nil
+}
+
+// packetLogLines returns the log lines referring to the packet with the given sequence,
+// e.g. "seq:5" or "sequence=5" in hermes log output.
+func packetLogLines(logs string, sequence uint64) []string {
+	pattern := regexp.MustCompile(fmt.Sprintf(`\b(seq|sequence)"?\s*[:=]\s*"?%d\b`, sequence))
+	var lines []string
+	for _, line := range strings.Split(logs, "\n") {
+		if pattern.MatchString(line) {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// settingsFor returns the config overrides for the given chain, creating them if necessary.
+func (r *Relayer) settingsFor(chainID string) *chainSettings {
+	if r.chainSettings == nil {
+		r.chainSettings = map[string]*chainSettings{}
+	}
+	if r.chainSettings[chainID] == nil {
+		r.chainSettings[chainID] = &chainSettings{}
+	}
+	return r.chainSettings[chainID]
+}
+
 // AddChainConfiguration is called once per chain configuration, which means that in the case of hermes, the single
 // config file is overwritten with a new entry each time this function is called.
 func (r *Relayer) AddChainConfiguration(ctx context.Context, rep ibc.RelayerExecReporter, chainConfig ibc.ChainConfig, keyName, rpcAddr, grpcAddr string) error {
+	if r.configOverride != nil {
+		return r.addChainConfigurationOverride(ctx, rep, chainConfig, keyName, rpcAddr, grpcAddr)
+	}
+
 	configContent, err := r.configContent(chainConfig, keyName, rpcAddr, grpcAddr)
 	if err != nil {
 		return fmt.Errorf("failed to generate config content: %w", err)
 	}
 
-	if err := r.WriteFileToHomeDir(ctx, hermesConfigPath, configContent); err != nil {
+	if err := r.WriteFileToHomeDir(ctx, r.c.relativeConfigPath(), configContent); err != nil {
 		return fmt.Errorf("failed to write hermes config: %w", err)
 	}
 
 	return r.validateConfig(ctx, rep)
 }
 
+// AddChain adds a chain to a relayer that may already be relaying. The chain is added to the config and,
+// if a mnemonic is given, its key is restored under keyName. Hermes does not reload its config while running,
+// so a running relayer is restarted on the paths it was started with to pick up the new chain.
+// An error is returned if the chain is already configured.
+func (r *Relayer) AddChain(ctx context.Context, rep ibc.RelayerExecReporter, chainConfig ibc.ChainConfig, keyName, rpcAddr, grpcAddr, mnemonic string) error {
+	if r.isConfigured(chainConfig.ChainID) {
+		return fmt.Errorf("chain %s is already configured", chainConfig.ChainID)
+	}
+	if err := r.AddChainConfiguration(ctx, rep, chainConfig, keyName, rpcAddr, grpcAddr); err != nil {
+		return err
+	}
+	if mnemonic != "" {
+		if err := r.RestoreKey(ctx, rep, chainConfig, keyName, mnemonic); err != nil {
+			return fmt.Errorf("failed to restore key for chain %s: %w", chainConfig.ChainID, err)
+		}
+	}
+
+	running, err := r.IsRunning(ctx)
+	if err != nil {
+		return err
+	}
+	if !running {
+		return nil
+	}
+	return r.DockerRelayer.RestartRelayer(ctx, rep, r.startedPaths...)
+}
+
+// addChainConfigurationOverride records the chain and writes the user supplied config file in place of a
+// generated one. The config is only validated once every chain it references has been added.
+func (r *Relayer) addChainConfigurationOverride(ctx context.Context, rep ibc.RelayerExecReporter, chainConfig ibc.ChainConfig, keyName, rpcAddr, grpcAddr string) error {
+	r.chainConfigs = append(r.chainConfigs, ChainConfig{
+		cfg:      chainConfig,
+		keyName:  keyName,
+		rpcAddr:  rpcAddr,
+		grpcAddr: grpcAddr,
+	})
+
+	configContent, complete, err := renderConfigOverride(r.configOverride, r.chainConfigs)
+	if err != nil {
+		return fmt.Errorf("failed to render hermes config override: %w", err)
+	}
+
+	if err := r.WriteFileToHomeDir(ctx, r.c.relativeConfigPath(), configContent); err != nil {
+		return fmt.Errorf("failed to write hermes config: %w", err)
+	}
+
+	if !complete {
+		return nil
+	}
+	return r.validateConfig(ctx, rep)
+}
+
 // LinkPath performs the operations that happen when a path is linked. This includes creating clients, creating connections
 // and establishing a channel. This happens across multiple operations rather than a single link path cli command.
 func (r *Relayer) LinkPath(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, channelOpts ibc.CreateChannelOptions, clientOpts ibc.CreateClientOptions) error {
-	_, ok := r.paths[pathName]
-	if !ok {
-		return fmt.Errorf("path %s not found", pathName)
+	if _, err := r.path(pathName); err != nil {
+		return err
 	}
 
 	if err := r.CreateClients(ctx, rep, pathName, clientOpts); err != nil {
@@ -113,13 +517,73 @@ func (r *Relayer) LinkPath(ctx context.Context, rep ibc.RelayerExecReporter, pat
 	return nil
 }
 
+const (
+	// defaultHandshakeAttempts is how many times a connection or channel handshake is attempted by default.
+	defaultHandshakeAttempts = 2
+	// defaultHandshakeBackoff is how long to wait before retrying a failed handshake by default.
+	defaultHandshakeBackoff = 5 * time.Second
+)
+
+// SetHandshakeRetries sets how many times the connection and channel handshakes of CreateConnections, CreateChannel
+// and LinkPath are attempted, so that a chain being briefly unavailable does not fail the test. The wait before
+// each retry starts at backoff and doubles with every attempt. By default, a handshake is attempted twice,
+// 5 seconds apart. Note that retrying a handshake that failed halfway starts a new one rather than resuming it.
+func (r *Relayer) SetHandshakeRetries(attempts int, backoff time.Duration) error {
+	if attempts < 1 {
+		return fmt.Errorf("invalid handshake attempts %d: must be at least 1", attempts)
+	}
+	if backoff < 0 {
+		return fmt.Errorf("invalid handshake backoff %s", backoff)
+	}
+	r.handshakeAttempts = attempts
+	r.handshakeBackoff = backoff
+	return nil
+}
+
+// execHandshake runs a handshake command, retrying it with backoff as configured through SetHandshakeRetries.
+// A command is not retried once ctx is done.
+func (r *Relayer) execHandshake(ctx context.Context, rep ibc.RelayerExecReporter, cmd []string) ibc.RelayerExecResult {
+	attempts, backoff := defaultHandshakeAttempts, defaultHandshakeBackoff
+	if r.handshakeAttempts > 0 {
+		attempts, backoff = r.handshakeAttempts, r.handshakeBackoff
+	}
+
+	var res ibc.RelayerExecResult
+	for attempt := 1; ; attempt++ {
+		res = r.exec(ctx, rep, cmd)
+		if res.Err == nil || attempt == attempts {
+			return res
+		}
+		r.c.log.Warn("Handshake failed, retrying",
+			zap.Int("attempt", attempt),
+			zap.Duration("backoff", backoff),
+			zap.Error(res.Err),
+		)
+
+		select {
+		case <-ctx.Done():
+			return res
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// CreateChannel creates a channel on the connection of the path, between the source and destination ports given in opts,
+// e.g. "transfer" for ICS-20 or an ICA controller and host port. Both ports are required.
 func (r *Relayer) CreateChannel(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, opts ibc.CreateChannelOptions) error {
-	pathConfig := r.paths[pathName]
-	cmd := []string{hermes, "--json", "create", "channel", "--order", opts.Order.String(), "--a-chain", pathConfig.chainA.chainID, "--a-port", opts.SourcePortName, "--b-port", opts.DestPortName, "--a-connection", pathConfig.chainA.connectionID}
+	pathConfig, err := r.path(pathName)
+	if err != nil {
+		return err
+	}
+	if opts.SourcePortName == "" || opts.DestPortName == "" {
+		return fmt.Errorf("create channel on path %s: source and destination ports are required", pathName)
+	}
+	cmd := r.c.hermesCmd(r.HomeDir(), "--json", "create", "channel", "--order", opts.Order.String(), "--a-chain", pathConfig.chainA.chainID, "--a-port", opts.SourcePortName, "--b-port", opts.DestPortName, "--a-connection", pathConfig.chainA.connectionID)
 	if opts.Version != "" {
 		cmd = append(cmd, "--channel-version", opts.Version)
 	}
-	res := r.Exec(ctx, rep, cmd, nil)
+	res := r.execHandshake(ctx, rep, cmd)
 	if res.Err != nil {
 		return res.Err
 	}
@@ -128,48 +592,172 @@ func (r *Relayer) CreateChannel(ctx context.Context, rep ibc.RelayerExecReporter
 	return nil
 }
 
+// CreateChannelOnPath opens an additional channel on the existing connection of the path, e.g. an ICA channel next to
+// the transfer channel created by LinkPath, without recreating the clients and connection.
+// An error is returned if the path has no connection or its connection is not open on chain A.
+func (r *Relayer) CreateChannelOnPath(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, opts ibc.CreateChannelOptions) error {
+	pathConfig, err := r.path(pathName)
+	if err != nil {
+		return err
+	}
+	chainID, connectionID := pathConfig.chainA.chainID, pathConfig.chainA.connectionID
+	if connectionID == "" {
+		return fmt.Errorf("path %s has no connection", pathName)
+	}
+	conn, err := ibc.GetConnection(ctx, r, rep, chainID, connectionID)
+	if err != nil {
+		return err
+	}
+	if !conn.IsOpen() {
+		return fmt.Errorf("connection %s of path %s is not open on %s (%s)", connectionID, pathName, chainID, conn.State)
+	}
+	return r.CreateChannel(ctx, rep, pathName, opts)
+}
+
+// PathPorts returns the ports of the source (chain A) and destination (chain B) ends of the channel created on the path,
+// e.g. "transfer" for both ends of a transfer channel, or an ICA controller and host port.
+// An error is returned if the path does not exist or no channel has been created on it yet.
+func (r *Relayer) PathPorts(pathName string) (srcPort, dstPort string, err error) {
+	pathConfig, err := r.path(pathName)
+	if err != nil {
+		return "", "", err
+	}
+	if pathConfig.chainA.portID == "" || pathConfig.chainB.portID == "" {
+		return "", "", fmt.Errorf("path %s has no channel", pathName)
+	}
+	return pathConfig.chainA.portID, pathConfig.chainB.portID, nil
+}
+
+// UseExistingClients configures the path to use clients that already exist on both chains,
+// so that CreateConnections reuses them rather than requiring CreateClients to be called first.
+// Each client must exist on its host chain and track the counterparty chain of the path.
+func (r *Relayer) UseExistingClients(ctx context.Context, rep ibc.RelayerExecReporter, pathName, srcClientID, dstClientID string) error {
+	pathConfig, err := r.path(pathName)
+	if err != nil {
+		return err
+	}
+	if err := r.checkClient(ctx, rep, pathConfig.chainA.chainID, srcClientID, pathConfig.chainB.chainID); err != nil {
+		return err
+	}
+	if err := r.checkClient(ctx, rep, pathConfig.chainB.chainID, dstClientID, pathConfig.chainA.chainID); err != nil {
+		return err
+	}
+	pathConfig.chainA.clientID = srcClientID
+	pathConfig.chainB.clientID = dstClientID
+	return nil
+}
+
+// checkClient verifies that the client exists on the host chain and tracks the given counterparty chain.
+func (r *Relayer) checkClient(ctx context.Context, rep ibc.RelayerExecReporter, hostChainID, clientID, counterpartyChainID string) error {
+	clients, err := r.GetClients(ctx, rep, hostChainID)
+	if err != nil {
+		return fmt.Errorf("failed to get clients on %s: %w", hostChainID, err)
+	}
+	for _, client := range clients {
+		if client.ClientID != clientID {
+			continue
+		}
+		if client.ClientState.ChainID != counterpartyChainID {
+			return fmt.Errorf("client %s on %s tracks %s, not %s", clientID, hostChainID, client.ClientState.ChainID, counterpartyChainID)
+		}
+		return nil
+	}
+	return fmt.Errorf("client %s not found on %s", clientID, hostChainID)
+}
+
 func (r *Relayer) CreateConnections(ctx context.Context, rep ibc.RelayerExecReporter, pathName string) error {
-	pathConfig := r.paths[pathName]
-	cmd := []string{hermes, "--json", "create", "connection", "--a-chain", pathConfig.chainA.chainID, "--a-client", pathConfig.chainA.clientID, "--b-client", pathConfig.chainB.clientID}
+	_, err := r.CreateConnectionsWithResult(ctx, rep, pathName)
+	return err
+}
+
+// CreateConnectionsWithResult performs the connection handshake for the given path, like CreateConnections,
+// and returns the connection and client identifiers on both ends.
+func (r *Relayer) CreateConnectionsWithResult(ctx context.Context, rep ibc.RelayerExecReporter, pathName string) (ibc.ConnectionHandshake, error) {
+	pathConfig, err := r.path(pathName)
+	if err != nil {
+		return ibc.ConnectionHandshake{}, err
+	}
+	cmd := r.c.hermesCmd(r.HomeDir(), "--json", "create", "connection", "--a-chain", pathConfig.chainA.chainID, "--a-client", pathConfig.chainA.clientID, "--b-client", pathConfig.chainB.clientID)
 
-	res := r.Exec(ctx, rep, cmd, nil)
+	res := r.execHandshake(ctx, rep, cmd)
 	if res.Err != nil {
-		return res.Err
+		return ibc.ConnectionHandshake{}, res.Err
 	}
 
-	chainAConnectionID, chainBConnectionID, err := getConnectionIDsFromStdout(res.Stdout)
+	handshake, err := parseConnectionHandshake(res.Stdout, pathConfig.chainA.chainID)
 	if err != nil {
-		return err
+		return ibc.ConnectionHandshake{}, err
 	}
-	pathConfig.chainA.connectionID = chainAConnectionID
-	pathConfig.chainB.connectionID = chainBConnectionID
-	return res.Err
+	pathConfig.chainA.connectionID = handshake.SrcConnID
+	pathConfig.chainB.connectionID = handshake.DstConnID
+	return handshake, nil
 }
 
 func (r *Relayer) UpdateClients(ctx context.Context, rep ibc.RelayerExecReporter, pathName string) error {
-	pathConfig, ok := r.paths[pathName]
-	if !ok {
-		return fmt.Errorf("path %s not found", pathName)
+	pathConfig, err := r.path(pathName)
+	if err != nil {
+		return err
 	}
-	updateChainACmd := []string{hermes, "--json", "update", "client", "--host-chain", pathConfig.chainA.chainID, "--client", pathConfig.chainA.clientID}
-	res := r.Exec(ctx, rep, updateChainACmd, nil)
+	updateChainACmd := r.c.hermesCmd(r.HomeDir(), "--json", "update", "client", "--host-chain", pathConfig.chainA.chainID, "--client", pathConfig.chainA.clientID)
+	res := r.exec(ctx, rep, updateChainACmd)
 	if res.Err != nil {
 		return res.Err
 	}
-	updateChainBCmd := []string{hermes, "--json", "update", "client", "--host-chain", pathConfig.chainB.chainID, "--client", pathConfig.chainB.clientID}
-	return r.Exec(ctx, rep, updateChainBCmd, nil).Err
+	updateChainBCmd := r.c.hermesCmd(r.HomeDir(), "--json", "update", "client", "--host-chain", pathConfig.chainB.chainID, "--client", pathConfig.chainB.clientID)
+	return r.exec(ctx, rep, updateChainBCmd).Err
+}
+
+// UpgradeClient upgrades the client hosted on chainID after the counterparty chain has halted for a software upgrade
+// at upgradeHeight. If clientID is empty, the client is resolved from the given path.
+func (r *Relayer) UpgradeClient(ctx context.Context, rep ibc.RelayerExecReporter, pathName, chainID, clientID string, upgradeHeight int64) error {
+	if clientID == "" {
+		pathConfig, err := r.path(pathName)
+		if err != nil {
+			return err
+		}
+		switch chainID {
+		case pathConfig.chainA.chainID:
+			clientID = pathConfig.chainA.clientID
+		case pathConfig.chainB.chainID:
+			clientID = pathConfig.chainB.clientID
+		default:
+			return fmt.Errorf("chain %s is not part of path %s", chainID, pathName)
+		}
+		if clientID == "" {
+			return fmt.Errorf("no client has been created on %s for path %s", chainID, pathName)
+		}
+	}
+
+	cmd := r.c.UpgradeClient(chainID, clientID, upgradeHeight, r.HomeDir())
+	return r.exec(ctx, rep, cmd).Err
 }
 
 // CreateClients creates clients on both chains.
 // Note: in the go relayer this can be done with a single command using the path reference,
 // however in Hermes this needs to be done as two separate commands.
 func (r *Relayer) CreateClients(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, opts ibc.CreateClientOptions) error {
-	pathConfig := r.paths[pathName]
-	chainACreateClientCmd := []string{hermes, "--json", "create", "client", "--host-chain", pathConfig.chainA.chainID, "--reference-chain", pathConfig.chainB.chainID}
-	if opts.TrustingPeriod != "0" {
-		chainACreateClientCmd = append(chainACreateClientCmd, "--trusting-period", opts.TrustingPeriod)
+	if opts.ClientType != "" && opts.ClientType != tendermintClientType {
+		// "hermes create client" creates clients of the type of the reference chain, which is tendermint for cosmos chains.
+		return fmt.Errorf("create client of type %s: %w", opts.ClientType, relayer.ErrUnsupportedCapability)
+	}
+	pathConfig, err := r.path(pathName)
+	if err != nil {
+		return err
+	}
+	if opts.TrustedHeight != 0 {
+		// Each client trusts the consensus state of its reference chain at the trusted height, which must exist already.
+		for _, chainID := range []string{pathConfig.chainA.chainID, pathConfig.chainB.chainID} {
+			height, err := r.QueryLatestHeight(ctx, rep, chainID)
+			if err != nil {
+				return fmt.Errorf("failed to query latest height of %s: %w", chainID, err)
+			}
+			if opts.TrustedHeight > height {
+				return fmt.Errorf("trusted height %d is ahead of the latest height %d of %s", opts.TrustedHeight, height, chainID)
+			}
+		}
 	}
-	res := r.Exec(ctx, rep, chainACreateClientCmd, nil)
+
+	res := r.exec(ctx, rep, r.createClientCmd(pathConfig.chainA.chainID, pathConfig.chainB.chainID, opts))
 	if res.Err != nil {
 		return res.Err
 	}
@@ -178,13 +766,12 @@ func (r *Relayer) CreateClients(ctx context.Context, rep ibc.RelayerExecReporter
 	if err != nil {
 		return err
 	}
+	if err := checkClientType(chainAClientId, opts.ClientType); err != nil {
+		return err
+	}
 	pathConfig.chainA.clientID = chainAClientId
 
-	chainBCreateClientCmd := []string{hermes, "--json", "create", "client", "--host-chain", pathConfig.chainB.chainID, "--reference-chain", pathConfig.chainA.chainID}
-	if opts.TrustingPeriod != "0" {
-		chainBCreateClientCmd = append(chainBCreateClientCmd, "--trusting-period", opts.TrustingPeriod)
-	}
-	res = r.Exec(ctx, rep, chainBCreateClientCmd, nil)
+	res = r.exec(ctx, rep, r.createClientCmd(pathConfig.chainB.chainID, pathConfig.chainA.chainID, opts))
 	if res.Err != nil {
 		return res.Err
 	}
@@ -193,11 +780,26 @@ func (r *Relayer) CreateClients(ctx context.Context, rep ibc.RelayerExecReporter
 	if err != nil {
 		return err
 	}
+	if err := checkClientType(chainBClientId, opts.ClientType); err != nil {
+		return err
+	}
 	pathConfig.chainB.clientID = chainBClientId
 
 	return res.Err
 }
 
+// createClientCmd returns the command to create a client on the host chain tracking the reference chain.
+func (r *Relayer) createClientCmd(hostChainID, referenceChainID string, opts ibc.CreateClientOptions) []string {
+	cmd := r.c.hermesCmd(r.HomeDir(), "--json", "create", "client", "--host-chain", hostChainID, "--reference-chain", referenceChainID)
+	if opts.TrustingPeriod != "0" {
+		cmd = append(cmd, "--trusting-period", opts.TrustingPeriod)
+	}
+	if opts.TrustedHeight != 0 {
+		cmd = append(cmd, "--trusted-height", strconv.FormatUint(opts.TrustedHeight, 10))
+	}
+	return cmd
+}
+
 // RestoreKey restores a key from a mnemonic. In hermes, you must provide a file containing the mnemonic. We need
 // to copy the contents of the mnemonic into a file on disk and then reference the newly created file.
 func (r *Relayer) RestoreKey(ctx context.Context, rep ibc.RelayerExecReporter, cfg ibc.ChainConfig, keyName, mnemonic string) error {
@@ -207,32 +809,471 @@ func (r *Relayer) RestoreKey(ctx context.Context, rep ibc.RelayerExecReporter, c
 		return fmt.Errorf("failed to write mnemonic file: %w", err)
 	}
 
-	cmd := []string{hermes, "keys", "add", "--chain", chainID, "--mnemonic-file", fmt.Sprintf("%s/%s", r.HomeDir(), relativeMnemonicFilePath), "--key-name", keyName}
+	cmd := r.c.hermesCmd(r.HomeDir(), "keys", "add", "--chain", chainID, "--mnemonic-file", fmt.Sprintf("%s/%s", r.HomeDir(), relativeMnemonicFilePath), "--key-name", keyName)
 
 	// Restoring a key should be near-instantaneous, so add a 1-minute timeout
 	// to detect if Docker has hung.
 	ctx, cancel := context.WithTimeout(ctx, time.Minute)
 	defer cancel()
 
-	res := r.Exec(ctx, rep, cmd, nil)
+	res := r.exec(ctx, rep, cmd)
 	if res.Err != nil {
 		return res.Err
 	}
 
-	addrBytes := parseRestoreKeyOutput(string(res.Stdout))
+	addrBytes, err := parseRestoreKeyOutput(string(res.Stdout))
+	if err != nil {
+		return err
+	}
 	r.AddWallet(chainID, NewWallet(chainID, addrBytes, mnemonic))
+	r.recordKey(chainID, keyName)
+	return nil
+}
+
+// Version returns the version reported by the hermes binary in the relayer image, e.g. "1.6.0+4b5b34e",
+// which may differ from the configured image tag. An error is returned if the binary cannot be run.
+func (r *Relayer) Version(ctx context.Context) (string, error) {
+	res := r.exec(ctx, ibc.NopRelayerExecReporter{}, []string{r.c.executable(), "version"})
+	if res.Err != nil {
+		return "", res.Err
+	}
+	return parseVersionOutput(res.Stdout)
+}
+
+// parseVersionOutput extracts the version from the stdout of hermes version.
+func parseVersionOutput(stdout []byte) (string, error) {
+	m := parseVersionOutputPattern.FindSubmatch(stdout)
+	if m == nil {
+		return "", parseError("version", fmt.Errorf("unexpected output %q", strings.TrimSpace(string(stdout))))
+	}
+	return string(m[1]), nil
+}
+
+// QueryLatestHeight returns the latest height of the given chain as seen by the relayer.
+// The chain must have been added through AddChainConfiguration.
+func (r *Relayer) QueryLatestHeight(ctx context.Context, rep ibc.RelayerExecReporter, chainID string) (uint64, error) {
+	if !r.isConfigured(chainID) {
+		return 0, fmt.Errorf("chain %s is not configured on the relayer", chainID)
+	}
+
+	res := r.exec(ctx, rep, r.c.QueryChainStatus(chainID, r.HomeDir()))
+	if res.Err != nil {
+		return 0, res.Err
+	}
+	return r.c.ParseQueryChainStatusOutput(string(res.Stdout), string(res.Stderr))
+}
+
+// ClientExpiry returns the time at which the client on the given host chain expires,
+// i.e. the timestamp of its latest consensus state plus its trusting period.
+func (r *Relayer) ClientExpiry(ctx context.Context, rep ibc.RelayerExecReporter, chainID, clientID string) (time.Time, error) {
+	res := r.exec(ctx, rep, r.c.hermesCmd(r.HomeDir(), "--json", "query", "client", "state", "--chain", chainID, "--client", clientID))
+	if res.Err != nil {
+		return time.Time{}, res.Err
+	}
+	trustingPeriod, height, err := parseClientState(res.Stdout)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	res = r.exec(ctx, rep, r.c.hermesCmd(r.HomeDir(), "--json", "query", "client", "consensus",
+		"--chain", chainID, "--client", clientID, "--consensus-height", strconv.FormatUint(height, 10)))
+	if res.Err != nil {
+		return time.Time{}, res.Err
+	}
+	updatedAt, err := parseConsensusTimestamp(res.Stdout)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return updatedAt.Add(trustingPeriod), nil
+}
+
+// WaitForClientExpired blocks until the expiry time of the client on the given host chain has passed,
+// or the context is done. The client is only considered expired by the host chain
+// once it produces a block after that time.
+func (r *Relayer) WaitForClientExpired(ctx context.Context, rep ibc.RelayerExecReporter, chainID, clientID string) error {
+	expiry, err := r.ClientExpiry(ctx, rep, chainID, clientID)
+	if err != nil {
+		return err
+	}
+	timer := time.NewTimer(time.Until(expiry))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("waiting for client %s on %s to expire at %s: %w", clientID, chainID, expiry, ctx.Err())
+	case <-timer.C:
+		return nil
+	}
+}
+
+// HeaderClient returns the block headers of a chain, e.g. the RPC client of a cosmos chain node.
+type HeaderClient interface {
+	Header(ctx context.Context, height *int64) (*coretypes.ResultHeader, error)
+}
+
+// CheckClientState verifies that the latest state of the client on the given host chain matches the counterparty
+// chain it tracks: the chain ID of the client must be the counterparty's, and the commitment root of the latest
+// consensus state of the client must be the app hash of the counterparty's block header at the same height.
+// The returned error describes every mismatch.
+func (r *Relayer) CheckClientState(ctx context.Context, rep ibc.RelayerExecReporter, chainID, clientID string, counterparty HeaderClient) error {
+	res := r.exec(ctx, rep, r.c.hermesCmd(r.HomeDir(), "--json", "query", "client", "state", "--chain", chainID, "--client", clientID))
+	if res.Err != nil {
+		return res.Err
+	}
+	state, err := parseClientStateResponse(res.Stdout)
+	if err != nil {
+		return err
+	}
+	height := state.Result.LatestHeight.RevisionHeight
+
+	res = r.exec(ctx, rep, r.c.hermesCmd(r.HomeDir(), "--json", "query", "client", "consensus",
+		"--chain", chainID, "--client", clientID, "--consensus-height", strconv.FormatUint(height, 10)))
+	if res.Err != nil {
+		return res.Err
+	}
+	root, err := parseConsensusRoot(res.Stdout)
+	if err != nil {
+		return err
+	}
+
+	h := int64(height)
+	header, err := counterparty.Header(ctx, &h)
+	if err != nil {
+		return fmt.Errorf("failed to get counterparty header at height %d: %w", height, err)
+	}
+
+	var merr error
+	if header.Header.ChainID != state.Result.ChainID {
+		multierr.AppendInto(&merr, fmt.Errorf("chain ID: client %s on %s tracks %s, counterparty is %s", clientID, chainID, state.Result.ChainID, header.Header.ChainID))
+	}
+	if uint64(header.Header.Height) != height {
+		multierr.AppendInto(&merr, fmt.Errorf("height: client %s on %s is at height %d, counterparty header is at height %d", clientID, chainID, height, header.Header.Height))
+	}
+	if !bytes.Equal(root, header.Header.AppHash) {
+		multierr.AppendInto(&merr, fmt.Errorf("app hash: client %s on %s has root %X at height %d, counterparty has app hash %X", clientID, chainID, root, height, []byte(header.Header.AppHash)))
+	}
+	return merr
+}
+
+// WaitForBlocks blocks until the given chain, as seen by the relayer, has advanced n blocks past its current height.
+// An error is returned if the chain produces no block within its max block time (see SetMaxBlockTime),
+// or the context is done.
+func (r *Relayer) WaitForBlocks(ctx context.Context, rep ibc.RelayerExecReporter, chainID string, n uint64) error {
+	stallTimeout := r.maxBlockTime
+	if stallTimeout == 0 {
+		stallTimeout = defaultMaxBlockTime
+	}
+	pollInterval := min(time.Second, stallTimeout/10)
+
+	start, err := r.QueryLatestHeight(ctx, rep, chainID)
+	if err != nil {
+		return err
+	}
+	current, advancedAt := start, time.Now()
+	for current < start+n {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for %d blocks on %s, at height %d: %w", n, chainID, current, ctx.Err())
+		case <-time.After(pollInterval):
+		}
+
+		height, err := r.QueryLatestHeight(ctx, rep, chainID)
+		if err != nil {
+			return err
+		}
+		if height > current {
+			current, advancedAt = height, time.Now()
+			continue
+		}
+		if time.Since(advancedAt) > stallTimeout {
+			return fmt.Errorf("chain %s stopped producing blocks at height %d: no new block within %s", chainID, current, stallTimeout)
+		}
+	}
+	return nil
+}
+
+// isConfigured reports whether the given chain has been added through AddChainConfiguration.
+func (r *Relayer) isConfigured(chainID string) bool {
+	for _, c := range r.chainConfigs {
+		if c.cfg.ChainID == chainID {
+			return true
+		}
+	}
+	return false
+}
+
+// ConfiguredChains returns the IDs of the chains added through AddChainConfiguration, in the order they were added.
+func (r *Relayer) ConfiguredChains() []string {
+	chainIDs := make([]string, 0, len(r.chainConfigs))
+	for _, c := range r.chainConfigs {
+		chainIDs = append(chainIDs, c.cfg.ChainID)
+	}
+	return chainIDs
+}
+
+// ListKeys returns the keys registered with hermes for the given chain, sorted by key name.
+func (r *Relayer) ListKeys(ctx context.Context, rep ibc.RelayerExecReporter, chainID string) ([]ibc.Wallet, error) {
+	cmd := r.c.hermesCmd(r.HomeDir(), "--json", "keys", "list", "--chain", chainID)
+	res := r.exec(ctx, rep, cmd)
+	if res.Err != nil {
+		return nil, res.Err
+	}
+	return parseKeysListOutput(res.Stdout)
+}
+
+// HasKey reports whether a key with the given name has been restored for the given chain.
+func (r *Relayer) HasKey(chainID, keyName string) bool {
+	_, ok := r.keys[chainID][keyName]
+	return ok
+}
+
+// recordKey tracks that the named key is available to the relayer for the given chain.
+func (r *Relayer) recordKey(chainID, keyName string) {
+	if r.keys == nil {
+		r.keys = map[string]map[string]struct{}{}
+	}
+	if r.keys[chainID] == nil {
+		r.keys[chainID] = map[string]struct{}{}
+	}
+	r.keys[chainID][keyName] = struct{}{}
+}
+
+// StartRelayer starts relaying on the given paths.
+// Hermes relays between every chain in its config from a single process, so one container services all of the
+// paths. Each path must have been registered with GeneratePath, and both of its chains must be configured.
+func (r *Relayer) StartRelayer(ctx context.Context, rep ibc.RelayerExecReporter, pathNames ...string) error {
+	if err := r.checkPaths(pathNames...); err != nil {
+		return err
+	}
+	if err := validateStartFlags(r.c.extraStartFlags); err != nil {
+		return err
+	}
+	if err := r.checkKeys(); err != nil {
+		return err
+	}
+	if err := r.DockerRelayer.StartRelayer(ctx, rep, pathNames...); err != nil {
+		return err
+	}
+	r.startedPaths = pathNames
+	return nil
+}
+
+// checkKeys verifies that every key selected through SetKeyName has been restored for its chain.
+func (r *Relayer) checkKeys() error {
+	for _, chainID := range r.ConfiguredChains() {
+		settings, ok := r.chainSettings[chainID]
+		if !ok || settings.keyName == "" {
+			continue
+		}
+		if !r.HasKey(chainID, settings.keyName) {
+			return fmt.Errorf("key %s selected for chain %s has not been restored", settings.keyName, chainID)
+		}
+	}
+	return nil
+}
+
+// PathNames returns the names of the paths registered through GeneratePath, in sorted order.
+func (r *Relayer) PathNames() []string {
+	names := make([]string, 0, len(r.paths))
+	for name := range r.paths {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// checkPaths verifies that every named path is known and that the chains at both ends of it are configured.
+func (r *Relayer) checkPaths(pathNames ...string) error {
+	for _, name := range pathNames {
+		path, err := r.path(name)
+		if err != nil {
+			return err
+		}
+		for _, chainID := range []string{path.chainA.chainID, path.chainB.chainID} {
+			if !r.isConfigured(chainID) {
+				return fmt.Errorf("path %s: chain %s is not configured", name, chainID)
+			}
+		}
+	}
 	return nil
 }
 
 func (r *Relayer) Flush(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, channelID string) error {
-	path := r.paths[pathName]
-	cmd := []string{hermes, "clear", "packets", "--chain", path.chainA.chainID, "--channel", channelID, "--port", path.chainA.portID}
-	res := r.Exec(ctx, rep, cmd, nil)
-	return res.Err
+	path, err := r.path(pathName)
+	if err != nil {
+		return err
+	}
+	return r.clearPackets(ctx, rep, path.chainA.chainID, path.chainA.portID, channelID)
+}
+
+// RelayOnce relays the pending packets and acknowledgements, in both directions, of every open channel on the
+// connection of the path and then returns. Unlike StartRelayer, no long-running hermes process is left behind,
+// which makes the relaying of a test deterministic.
+func (r *Relayer) RelayOnce(ctx context.Context, rep ibc.RelayerExecReporter, pathName string) error {
+	path, err := r.path(pathName)
+	if err != nil {
+		return err
+	}
+	if path.chainA.connectionID == "" {
+		return fmt.Errorf("path %s has no connection", pathName)
+	}
+
+	channels, err := r.GetChannels(ctx, rep, path.chainA.chainID)
+	if err != nil {
+		return fmt.Errorf("failed to get channels on %s: %w", path.chainA.chainID, err)
+	}
+	for _, channel := range channels {
+		if !slices.Contains(channel.ConnectionHops, path.chainA.connectionID) || !channel.IsOpen() {
+			continue
+		}
+		if err := r.clearPackets(ctx, rep, path.chainA.chainID, channel.PortID, channel.ChannelID); err != nil {
+			return fmt.Errorf("failed to relay packets on %s/%s: %w", channel.PortID, channel.ChannelID, err)
+		}
+	}
+	return nil
+}
+
+// pathOpenPollInterval is how often WaitForPathOpen queries the state of a path.
+const pathOpenPollInterval = time.Second
+
+// WaitForPathOpen waits until the connection of the path and every channel on it are open on both chains,
+// as channels created by LinkPath and CreateChannel may complete their handshakes asynchronously.
+// If the path is not open within the timeout, the returned error lists the connections and channels that are not.
+func (r *Relayer) WaitForPathOpen(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, timeout time.Duration) error {
+	path, err := r.path(pathName)
+	if err != nil {
+		return err
+	}
+	if path.chainA.connectionID == "" {
+		return fmt.Errorf("path %s has no connection", pathName)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// last holds the connections and channels not open as of the last complete poll, which are reported
+	// if the timeout elapses while querying.
+	var last []string
+	polled := false
+	for {
+		var lagging []string
+		var err error
+		for _, end := range []pathChainConfig{path.chainA, path.chainB} {
+			if end.connectionID == "" {
+				continue
+			}
+			var notOpen []string
+			if notOpen, err = r.notOpen(ctx, rep, end.chainID, end.connectionID); err != nil {
+				break
+			}
+			lagging = append(lagging, notOpen...)
+		}
+		switch {
+		case err == nil && len(lagging) == 0:
+			return nil
+		case err == nil:
+			last, polled = lagging, true
+		case ctx.Err() == nil:
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			if !polled {
+				return fmt.Errorf("path %s not open after %s, no poll completed (%v): %w", pathName, timeout, err, ctx.Err())
+			}
+			return fmt.Errorf("path %s not open after %s, waiting on %s: %w", pathName, timeout, strings.Join(last, ", "), ctx.Err())
+		case <-time.After(pathOpenPollInterval):
+		}
+	}
+}
+
+// notOpen describes the given connection on a chain, and the channels on it, that are not yet open.
+func (r *Relayer) notOpen(ctx context.Context, rep ibc.RelayerExecReporter, chainID, connectionID string) ([]string, error) {
+	var lagging []string
+	conn, err := ibc.GetConnection(ctx, r, rep, chainID, connectionID)
+	switch {
+	case errors.Is(err, ibc.ErrConnectionNotFound):
+		lagging = append(lagging, fmt.Sprintf("%s on %s (not found)", connectionID, chainID))
+	case err != nil:
+		return nil, err
+	case !conn.IsOpen():
+		lagging = append(lagging, fmt.Sprintf("%s on %s (%s)", connectionID, chainID, conn.State))
+	}
+
+	channels, err := r.GetChannels(ctx, rep, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get channels on %s: %w", chainID, err)
+	}
+	for _, channel := range channels {
+		if slices.Contains(channel.ConnectionHops, connectionID) && !channel.IsOpen() {
+			lagging = append(lagging, fmt.Sprintf("%s/%s on %s (%s)", channel.PortID, channel.ChannelID, chainID, channel.State))
+		}
+	}
+	return lagging, nil
+}
+
+// CloseChannel closes the given channel on chain A of the path, and then its counterparty on chain B.
+// The close handshake is driven by hermes directly, so it can be used to close a channel whose relaying has stopped,
+// e.g. after its client expired, as long as the client on chain B can still be updated to prove the closure.
+// Note that ICS-20 transfer channels reject being closed by a relayer; such channels close on a packet timeout
+// if they are ordered.
+func (r *Relayer) CloseChannel(ctx context.Context, rep ibc.RelayerExecReporter, pathName, channelID string) error {
+	path, err := r.path(pathName)
+	if err != nil {
+		return err
+	}
+	ch, err := ibc.GetChannel(ctx, r, rep, path.chainA.chainID, channelID)
+	if err != nil {
+		return err
+	}
+	a := channelEnd{chainID: path.chainA.chainID, connectionID: path.chainA.connectionID, portID: ch.PortID, channelID: ch.ChannelID}
+	b := channelEnd{chainID: path.chainB.chainID, connectionID: path.chainB.connectionID, portID: ch.Counterparty.PortID, channelID: ch.Counterparty.ChannelID}
+
+	for _, step := range []struct {
+		tx, event string
+		dst, src  channelEnd
+	}{
+		{tx: "chan-close-init", event: "CloseInitChannel", dst: a, src: b},
+		{tx: "chan-close-confirm", event: "CloseConfirmChannel", dst: b, src: a},
+	} {
+		res := r.exec(ctx, rep, r.c.channelCloseCmd(r.HomeDir(), step.tx, step.dst, step.src))
+		if res.Err != nil {
+			return res.Err
+		}
+		if err := parseChannelClose(res.Stdout, step.event, step.dst.channelID); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-// GeneratePath establishes an in memory path representation. The concept does not exist in hermes, so it is handled
-// at the interchain test level.
+// RelayTimeouts would relay only the timeouts of the packets pending on the given channel of chain A of the path,
+// so that their senders are refunded while deliverable packets stay pending.
+//
+// Hermes cannot do so: it has no timeout-only command, and the timeouts of pending packets cannot be queried through
+// it to select the timed out ones with --packet-sequences, so "tx packet-recv" would deliver every deliverable packet
+// too. RelayTimeouts therefore runs no command and returns an error wrapping relayer.ErrUnsupportedCapability.
+func (r *Relayer) RelayTimeouts(ctx context.Context, rep ibc.RelayerExecReporter, pathName, channelID string) (int, error) {
+	if _, err := r.path(pathName); err != nil {
+		return 0, err
+	}
+	return 0, fmt.Errorf("relay timeouts on %s without delivering packets: %w", channelID, relayer.ErrUnsupportedCapability)
+}
+
+// path returns the configuration of the given path, or an error wrapping ErrPathNotFound
+// if the path has not been created through GeneratePath.
+func (r *Relayer) path(pathName string) (*pathConfiguration, error) {
+	path, ok := r.paths[pathName]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrPathNotFound, pathName)
+	}
+	return path, nil
+}
+
+// GeneratePath registers an in memory path between the given chains, as the concept does not exist in hermes.
+// The source chain is the a-side of the path and the destination chain the b-side: CreateClients, CreateConnections
+// and CreateChannel all run their handshakes from the a-side, and report the identifiers created on it as the source
+// ones. SetASide changes the assignment.
 func (r *Relayer) GeneratePath(ctx context.Context, rep ibc.RelayerExecReporter, srcChainID, dstChainID, pathName string) error {
 	if r.paths == nil {
 		r.paths = map[string]*pathConfiguration{}
@@ -248,6 +1289,32 @@ func (r *Relayer) GeneratePath(ctx context.Context, rep ibc.RelayerExecReporter,
 	return nil
 }
 
+// SetASide makes the given chain the a-side of the path, swapping the sides of the path if it was the b-side.
+// Clients, connections and channels created afterwards originate from the new a-side.
+func (r *Relayer) SetASide(pathName, chainID string) error {
+	path, err := r.path(pathName)
+	if err != nil {
+		return err
+	}
+	switch chainID {
+	case path.chainA.chainID:
+	case path.chainB.chainID:
+		path.chainA, path.chainB = path.chainB, path.chainA
+	default:
+		return fmt.Errorf("chain %s is not on path %s", chainID, pathName)
+	}
+	return nil
+}
+
+// PathSides returns the IDs of the chains on the a-side and b-side of the path.
+func (r *Relayer) PathSides(pathName string) (aChainID, bChainID string, err error) {
+	path, err := r.path(pathName)
+	if err != nil {
+		return "", "", err
+	}
+	return path.chainA.chainID, path.chainB.chainID, nil
+}
+
 // configContent returns the contents of the hermes config file as a byte array. Note: as hermes expects a single file
 // rather than multiple config files, we need to maintain a list of chain configs each time they are added to write the
 // full correct file update calling Relayer.AddChainConfiguration.
@@ -258,18 +1325,92 @@ func (r *Relayer) configContent(cfg ibc.ChainConfig, keyName, rpcAddr, grpcAddr
 		rpcAddr:  rpcAddr,
 		grpcAddr: grpcAddr,
 	})
-	hermesConfig := NewConfig(r.chainConfigs...)
-	bz, err := toml.Marshal(hermesConfig)
+	bz, err := toml.Marshal(r.generateConfig())
 	if err != nil {
 		return nil, err
 	}
 	return bz, nil
 }
 
+// generateConfig returns the hermes config for the chains configured so far, with every override applied.
+func (r *Relayer) generateConfig() Config {
+	hermesConfig := NewConfig(r.chainConfigs...)
+	if r.packetLogging {
+		hermesConfig.Global.LogLevel = "trace"
+	}
+	if r.loadTest {
+		applyLoadTestPreset(&hermesConfig)
+	}
+	if r.telemetry {
+		hermesConfig.Telemetry = Telemetry{
+			Enabled: true,
+			Host:    "0.0.0.0",
+			Port:    telemetryPort,
+		}
+	}
+	if r.txConfirmation != nil {
+		hermesConfig.Mode.Packets.TxConfirmation = *r.txConfirmation
+	}
+	for i := range hermesConfig.Chains {
+		if r.rpcTimeout != 0 {
+			hermesConfig.Chains[i].RPCTimeout = hermesDuration(r.rpcTimeout)
+		}
+		if r.maxBlockTime != 0 {
+			hermesConfig.Chains[i].MaxBlockTime = hermesDuration(r.maxBlockTime)
+		}
+		if settings, ok := r.chainSettings[hermesConfig.Chains[i].ID]; ok {
+			settings.apply(&hermesConfig.Chains[i])
+		}
+	}
+	return hermesConfig
+}
+
+// hermesDuration formats a duration for the hermes config, which does not accept Go's compound duration format.
+func hermesDuration(d time.Duration) string {
+	return fmt.Sprintf("%dms", d.Milliseconds())
+}
+
+// renderConfigOverride executes the config override template against the chains configured so far.
+// The returned bool reports whether every chain referenced by the template was known.
+func renderConfigOverride(content []byte, chainConfigs []ChainConfig) ([]byte, bool, error) {
+	chains := make(map[string]ChainConfig, len(chainConfigs))
+	for _, c := range chainConfigs {
+		chains[c.cfg.ChainID] = c
+	}
+
+	complete := true
+	lookup := func(chainID string, addr func(ChainConfig) string) string {
+		c, ok := chains[chainID]
+		if !ok {
+			complete = false
+			return ""
+		}
+		return addr(c)
+	}
+
+	tmpl, err := template.New("config.toml").Funcs(template.FuncMap{
+		"rpc": func(chainID string) string {
+			return lookup(chainID, func(c ChainConfig) string { return c.rpcAddr })
+		},
+		"grpc": func(chainID string) string {
+			return lookup(chainID, func(c ChainConfig) string { return fmt.Sprintf("http://%s", c.grpcAddr) })
+		},
+	}).Parse(string(content))
+	if err != nil {
+		return nil, false, err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, nil); err != nil {
+		return nil, false, err
+	}
+	return buf.Bytes(), complete, nil
+}
+
 // validateConfig validates the hermes config file. Any errors are propagated to the test.
 func (r *Relayer) validateConfig(ctx context.Context, rep ibc.RelayerExecReporter) error {
-	cmd := []string{hermes, "--config", fmt.Sprintf("%s/%s", r.HomeDir(), hermesConfigPath), "config", "validate"}
-	res := r.Exec(ctx, rep, cmd, nil)
+	cmd := r.c.hermesCmd(r.HomeDir(), "config", "validate")
+	res := r.exec(ctx, rep, cmd)
 	if res.Err != nil {
 		return res.Err
 	}
@@ -293,22 +1434,127 @@ func extractJsonResult(stdout []byte) []byte {
 func getClientIdFromStdout(stdout []byte) (string, error) {
 	var clientCreationResult ClientCreationResponse
 	if err := json.Unmarshal(extractJsonResult(stdout), &clientCreationResult); err != nil {
-		return "", err
+		return "", parseError("create client", err)
 	}
 	return clientCreationResult.Result.CreateClient.ClientID, nil
 }
 
-// getConnectionIDsFromStdout extracts the connectionIDs on both ends from the stdout.
-func getConnectionIDsFromStdout(stdout []byte) (string, string, error) {
+// checkClientType returns an error if the client ID is not prefixed by the given client type, if any.
+func checkClientType(clientID, clientType string) error {
+	if clientType != "" && !strings.HasPrefix(clientID, clientType+"-") {
+		return fmt.Errorf("created client %s is not of the requested type %s", clientID, clientType)
+	}
+	return nil
+}
+
+// parseConnectionHandshake extracts the connection and client identifiers on both ends from the stdout.
+func parseConnectionHandshake(stdout []byte, aChainID string) (ibc.ConnectionHandshake, error) {
 	var connectionResponse ConnectionResponse
 	if err := json.Unmarshal(extractJsonResult(stdout), &connectionResponse); err != nil {
-		return "", "", err
+		return ibc.ConnectionHandshake{}, parseError("create connection", err)
+	}
+	res := connectionResponse.Result
+	if id := res.ASide.Chain.ID; id != "" && id != aChainID {
+		return ibc.ConnectionHandshake{}, fmt.Errorf("connection created with %s as a-side, expected %s", id, aChainID)
+	}
+	return ibc.ConnectionHandshake{
+		SrcConnID:   res.ASide.ConnectionID,
+		DstConnID:   res.BSide.ConnectionID,
+		SrcClientID: res.ASide.ClientID,
+		DstClientID: res.BSide.ClientID,
+	}, nil
+}
+
+// parseChannelClose checks that the stdout of a channel close tx reports the expected event for the given channel.
+func parseChannelClose(stdout []byte, event, channelID string) error {
+	var resp ChannelCloseResponse
+	if err := json.Unmarshal(extractJsonResult(stdout), &resp); err != nil {
+		return parseError("channel close", err)
 	}
-	return connectionResponse.Result.ASide.ConnectionID, connectionResponse.Result.BSide.ConnectionID, nil
+	closed, ok := resp.Result[event]
+	if !ok {
+		return parseError("channel close", fmt.Errorf("no %s event", event))
+	}
+	if closed.ChannelID != channelID {
+		return fmt.Errorf("%s event for %s, expected %s", event, closed.ChannelID, channelID)
+	}
+	return nil
+}
+
+// parseKeysListOutput extracts the name and address of each key from the stdout of "keys list".
+func parseKeysListOutput(stdout []byte) ([]ibc.Wallet, error) {
+	var resp KeysListResponse
+	if err := json.Unmarshal(extractJsonResult(stdout), &resp); err != nil {
+		return nil, parseError("keys list", err)
+	}
+
+	names := make([]string, 0, len(resp.Result))
+	for name := range resp.Result {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	wallets := make([]ibc.Wallet, 0, len(names))
+	for _, name := range names {
+		wallets = append(wallets, NewWallet(name, resp.Result[name].Account, ""))
+	}
+	return wallets, nil
+}
+
+// parseClientState extracts the trusting period and latest height from the output of "query client state".
+func parseClientState(stdout []byte) (time.Duration, uint64, error) {
+	resp, err := parseClientStateResponse(stdout)
+	if err != nil {
+		return 0, 0, err
+	}
+	trustingPeriod := time.Duration(resp.Result.TrustingPeriod.Secs)*time.Second + time.Duration(resp.Result.TrustingPeriod.Nanos)
+	if trustingPeriod == 0 {
+		return 0, 0, parseError("client state", errors.New("missing trusting period"))
+	}
+	return trustingPeriod, resp.Result.LatestHeight.RevisionHeight, nil
+}
+
+// parseClientStateResponse parses the output of "query client state".
+func parseClientStateResponse(stdout []byte) (ClientStateResponse, error) {
+	var resp ClientStateResponse
+	if err := json.Unmarshal(extractJsonResult(stdout), &resp); err != nil {
+		return ClientStateResponse{}, parseError("client state", err)
+	}
+	return resp, nil
+}
+
+// parseConsensusRoot extracts the commitment root from the output of "query client consensus".
+func parseConsensusRoot(stdout []byte) ([]byte, error) {
+	var resp ConsensusStateResponse
+	if err := json.Unmarshal(extractJsonResult(stdout), &resp); err != nil {
+		return nil, parseError("consensus state", err)
+	}
+	root, err := hex.DecodeString(resp.Result.Root)
+	if err != nil {
+		return nil, parseError("consensus state", err)
+	}
+	return root, nil
+}
+
+// parseConsensusTimestamp extracts the timestamp from the output of "query client consensus".
+func parseConsensusTimestamp(stdout []byte) (time.Time, error) {
+	var resp ConsensusStateResponse
+	if err := json.Unmarshal(extractJsonResult(stdout), &resp); err != nil {
+		return time.Time{}, parseError("consensus state", err)
+	}
+	timestamp, err := time.Parse(time.RFC3339Nano, resp.Result.Timestamp)
+	if err != nil {
+		return time.Time{}, parseError("consensus state", err)
+	}
+	return timestamp, nil
 }
 
 // parseRestoreKeyOutput extracts the address from the hermes output.
-func parseRestoreKeyOutput(stdout string) string {
+func parseRestoreKeyOutput(stdout string) (string, error) {
 	fullMatchIdx, addressGroupIdx := 0, 1
-	return parseRestoreKeyOutputPattern.FindAllStringSubmatch(stdout, -1)[fullMatchIdx][addressGroupIdx]
+	matches := parseRestoreKeyOutputPattern.FindAllStringSubmatch(stdout, -1)
+	if len(matches) == 0 {
+		return "", parseError("restore key", fmt.Errorf("no address found in %q", stdout))
+	}
+	return matches[fullMatchIdx][addressGroupIdx], nil
 }
@@ -0,0 +1,697 @@
+package hermes
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/internal/dockerutil"
+	"github.com/strangelove-ventures/interchaintest/v8/relayer"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestConfiguredChainsAndKeys(t *testing.T) {
+	r := &Relayer{}
+	require.Empty(t, r.ConfiguredChains())
+	require.False(t, r.HasKey("gaia-1", "relayer"))
+
+	for _, chainID := range []string{"gaia-1", "osmosis-1"} {
+		_, err := r.configContent(ibc.ChainConfig{ChainID: chainID, Denom: "stake", GasPrices: "0.01stake"}, "relayer", "http://rpc:26657", "grpc:9090")
+		require.NoError(t, err)
+	}
+	require.Equal(t, []string{"gaia-1", "osmosis-1"}, r.ConfiguredChains())
+
+	r.recordKey("gaia-1", "relayer")
+	require.True(t, r.HasKey("gaia-1", "relayer"))
+	require.False(t, r.HasKey("gaia-1", "other"))
+	require.False(t, r.HasKey("osmosis-1", "relayer"))
+}
+
+func TestMultiplePaths(t *testing.T) {
+	ctx := context.Background()
+	r := &Relayer{c: &commander{log: zap.NewNop()}}
+
+	for _, chainID := range []string{"gaia-1", "osmosis-1", "juno-1"} {
+		_, err := r.configContent(ibc.ChainConfig{ChainID: chainID, Denom: "stake", GasPrices: "0.01stake"}, "relayer", "http://rpc:26657", "grpc:9090")
+		require.NoError(t, err)
+	}
+	require.NoError(t, r.GeneratePath(ctx, ibc.NopRelayerExecReporter{}, "gaia-1", "osmosis-1", "gaia-osmo"))
+	require.NoError(t, r.GeneratePath(ctx, ibc.NopRelayerExecReporter{}, "osmosis-1", "juno-1", "osmo-juno"))
+	require.NoError(t, r.GeneratePath(ctx, ibc.NopRelayerExecReporter{}, "juno-1", "gaia-1", "juno-gaia"))
+
+	require.Equal(t, []string{"gaia-osmo", "juno-gaia", "osmo-juno"}, r.PathNames())
+	require.NoError(t, r.checkPaths(r.PathNames()...))
+	require.Equal(t, "osmosis-1", r.paths["osmo-juno"].chainA.chainID)
+	require.Equal(t, "gaia-1", r.paths["juno-gaia"].chainB.chainID)
+
+	// A single hermes process services every path.
+	require.Equal(t, []string{"hermes", "--config", "/home/hermes/.hermes/config.toml", "start"},
+		startRelayerCmd(t, r.c, "/home/hermes", r.PathNames()...))
+
+	require.ErrorIs(t, r.checkPaths("gaia-osmo", "missing"), ErrPathNotFound)
+
+	require.NoError(t, r.GeneratePath(ctx, ibc.NopRelayerExecReporter{}, "gaia-1", "stride-1", "gaia-stride"))
+	require.ErrorContains(t, r.checkPaths("gaia-stride"), "chain stride-1 is not configured")
+}
+
+func TestUpgradeClientResolvesPath(t *testing.T) {
+	ctx := context.Background()
+	r := &Relayer{paths: map[string]*pathConfiguration{
+		"p": {
+			chainA: pathChainConfig{chainID: "gaia-1", clientID: "07-tendermint-0"},
+			chainB: pathChainConfig{chainID: "osmosis-1"},
+		},
+	}}
+
+	require.ErrorIs(t, r.UpgradeClient(ctx, ibc.NopRelayerExecReporter{}, "missing", "gaia-1", "", 10), ErrPathNotFound)
+	require.ErrorContains(t, r.UpgradeClient(ctx, ibc.NopRelayerExecReporter{}, "p", "juno-1", "", 10), "not part of path")
+	require.ErrorContains(t, r.UpgradeClient(ctx, ibc.NopRelayerExecReporter{}, "p", "osmosis-1", "", 10), "no client has been created")
+}
+
+func TestCreateClientsWithExecutor(t *testing.T) {
+	ctx := context.Background()
+
+	f := newFakeExecutor(t, map[string][]ibc.RelayerExecResult{
+		"create client": {cannedOutput(t, "create_client.json", "07-tendermint-0"), cannedOutput(t, "create_client.json", "07-tendermint-1")},
+	})
+	r := f.relayer()
+
+	require.NoError(t, r.GeneratePath(ctx, ibc.NopRelayerExecReporter{}, "gaia-1", "osmosis-1", "p"))
+	require.NoError(t, r.CreateClients(ctx, ibc.NopRelayerExecReporter{}, "p", ibc.CreateClientOptions{TrustingPeriod: "336h"}))
+
+	require.Equal(t, [][]string{
+		{"hermes", "--config", "/home/hermes/.hermes/config.toml", "--json", "create", "client", "--host-chain", "gaia-1", "--reference-chain", "osmosis-1", "--trusting-period", "336h"},
+		{"hermes", "--config", "/home/hermes/.hermes/config.toml", "--json", "create", "client", "--host-chain", "osmosis-1", "--reference-chain", "gaia-1", "--trusting-period", "336h"},
+	}, f.cmds)
+	require.Equal(t, "07-tendermint-0", r.paths["p"].chainA.clientID)
+	require.Equal(t, "07-tendermint-1", r.paths["p"].chainB.clientID)
+}
+
+func TestRelayOnce(t *testing.T) {
+	ctx := context.Background()
+
+	f := newFakeExecutor(t, map[string][]ibc.RelayerExecResult{
+		"query channels": {cannedOutput(t, "channels_two_connections.json")},
+		"clear packets":  {cannedOutput(t, "clear_packets.txt")},
+	})
+	r := f.relayer()
+	require.NoError(t, r.GeneratePath(ctx, ibc.NopRelayerExecReporter{}, "gaia-1", "osmosis-1", "p"))
+
+	require.ErrorContains(t, r.RelayOnce(ctx, ibc.NopRelayerExecReporter{}, "p"), "path p has no connection")
+	r.paths["p"].chainA.connectionID = "connection-0"
+
+	require.NoError(t, r.RelayOnce(ctx, ibc.NopRelayerExecReporter{}, "p"))
+	require.Equal(t, [][]string{
+		{"clear", "packets", "--chain", "gaia-1", "--channel", "channel-0", "--port", "transfer"},
+		{"clear", "packets", "--chain", "gaia-1", "--channel", "channel-1", "--port", "icahost"},
+	}, f.commands("clear packets"))
+
+	running, err := r.IsRunning(ctx)
+	require.NoError(t, err)
+	require.False(t, running)
+}
+
+func TestWaitForPathOpen(t *testing.T) {
+	ctx := context.Background()
+
+	// The ica channel completes its handshake on both chains after the first poll.
+	f := newFakeExecutor(t, map[string][]ibc.RelayerExecResult{
+		"query connections": {cannedOutput(t, "connections_open.json")},
+		"query channels": {
+			cannedOutput(t, "channels_ica_tryopen.json"),
+			cannedOutput(t, "channels_ica_tryopen.json"),
+			cannedOutput(t, "channels_ica_open.json"),
+		},
+	})
+	r := f.relayer()
+	require.NoError(t, r.GeneratePath(ctx, ibc.NopRelayerExecReporter{}, "gaia-1", "osmosis-1", "p"))
+
+	require.ErrorContains(t, r.WaitForPathOpen(ctx, ibc.NopRelayerExecReporter{}, "p", time.Second), "path p has no connection")
+	r.paths["p"].chainA.connectionID = "connection-0"
+	r.paths["p"].chainB.connectionID = "connection-0"
+
+	require.NoError(t, r.WaitForPathOpen(ctx, ibc.NopRelayerExecReporter{}, "p", 10*time.Second))
+	require.Len(t, f.commands("query channels"), 4)
+
+	f.respond("query channels", cannedOutput(t, "channels_ica_tryopen.json"))
+	err := r.WaitForPathOpen(ctx, ibc.NopRelayerExecReporter{}, "p", 10*time.Millisecond)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.ErrorContains(t, err, "waiting on icahost/channel-1 on gaia-1 (TryOpen), icahost/channel-1 on osmosis-1 (TryOpen)")
+
+	// A query interrupted by the deadline reports the channels not open as of the last poll.
+	f.respond("query channels", cannedOutput(t, "channels_ica_tryopen.json"), cannedOutput(t, "channels_ica_tryopen.json"), hangingResult)
+	err = r.WaitForPathOpen(ctx, ibc.NopRelayerExecReporter{}, "p", 1500*time.Millisecond)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.ErrorContains(t, err, "waiting on icahost/channel-1 on gaia-1 (TryOpen), icahost/channel-1 on osmosis-1 (TryOpen)")
+}
+
+func TestClientExpiry(t *testing.T) {
+	ctx := context.Background()
+
+	f := newFakeExecutor(t, map[string][]ibc.RelayerExecResult{
+		"query client state":     {cannedOutput(t, "client_state.json")},
+		"query client consensus": {cannedOutput(t, "client_consensus.json")},
+	})
+	r := f.relayer()
+
+	expiry, err := r.ClientExpiry(ctx, ibc.NopRelayerExecReporter{}, "gaia-1", "07-tendermint-0")
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, 3, 15, 12, 0, 0, 500_000_000, time.UTC), expiry)
+	require.Equal(t, []string{
+		"hermes", "--config", "/home/hermes/.hermes/config.toml", "--json", "query", "client", "consensus",
+		"--chain", "gaia-1", "--client", "07-tendermint-0", "--consensus-height", "42",
+	}, f.cmds[1])
+
+	// The expiry is in the past, so the client has already expired.
+	require.NoError(t, r.WaitForClientExpired(ctx, ibc.NopRelayerExecReporter{}, "gaia-1", "07-tendermint-0"))
+
+	_, _, err = parseClientState([]byte(`{"result":{"chain_id":"osmosis-1"},"status":"success"}`))
+	require.ErrorIs(t, err, ErrParseOutput)
+}
+
+func TestCheckClientState(t *testing.T) {
+	ctx := context.Background()
+
+	f := newFakeExecutor(t, map[string][]ibc.RelayerExecResult{
+		"query client state":     {cannedOutput(t, "client_state.json")},
+		"query client consensus": {cannedOutput(t, "client_consensus.json")},
+	})
+	r := f.relayer()
+
+	matching := fakeHeaderClient{header: cmttypes.Header{ChainID: "osmosis-1", AppHash: []byte{0xa1, 0xb2, 0xc3, 0xd4}}}
+	require.NoError(t, r.CheckClientState(ctx, ibc.NopRelayerExecReporter{}, "gaia-1", "07-tendermint-0", matching))
+
+	mismatching := fakeHeaderClient{header: cmttypes.Header{ChainID: "juno-1", Height: 43, AppHash: []byte{0xff}}}
+	err := r.CheckClientState(ctx, ibc.NopRelayerExecReporter{}, "gaia-1", "07-tendermint-0", mismatching)
+	require.ErrorContains(t, err, "chain ID: client 07-tendermint-0 on gaia-1 tracks osmosis-1, counterparty is juno-1")
+	require.ErrorContains(t, err, "height: client 07-tendermint-0 on gaia-1 is at height 42, counterparty header is at height 43")
+	require.ErrorContains(t, err, "app hash: client 07-tendermint-0 on gaia-1 has root A1B2C3D4 at height 42, counterparty has app hash FF")
+
+	_, err = parseConsensusRoot([]byte(`{"result":{"root":"not hex"},"status":"success"}`))
+	require.ErrorIs(t, err, ErrParseOutput)
+}
+
+func TestAssertCounterparty(t *testing.T) {
+	ctx := context.Background()
+
+	f := newFakeExecutor(t, map[string][]ibc.RelayerExecResult{
+		"query channels": {cannedOutput(t, "channels_ica_controller.json")},
+	})
+	r := f.relayer()
+
+	require.NoError(t, ibc.AssertCounterparty(ctx, r, ibc.NopRelayerExecReporter{}, "gaia-1", "channel-1", "icahost", "channel-9"))
+
+	err := ibc.AssertCounterparty(ctx, r, ibc.NopRelayerExecReporter{}, "gaia-1", "channel-1", "icahost", "channel-0")
+	require.EqualError(t, err, "channel channel-1 on gaia-1 has counterparty icahost/channel-9, expected icahost/channel-0")
+
+	err = ibc.AssertCounterparty(ctx, r, ibc.NopRelayerExecReporter{}, "gaia-1", "channel-1", "transfer", "channel-9")
+	require.ErrorContains(t, err, "expected transfer/channel-9")
+
+	err = ibc.AssertCounterparty(ctx, r, ibc.NopRelayerExecReporter{}, "gaia-1", "channel-7", "icahost", "channel-9")
+	require.ErrorIs(t, err, ibc.ErrChannelNotFound)
+}
+
+func TestCreateChannelPorts(t *testing.T) {
+	ctx := context.Background()
+
+	f := newFakeExecutor(t, map[string][]ibc.RelayerExecResult{
+		"create channel": {cannedOutput(t, "empty_object.json")},
+	})
+	r := f.relayer()
+	require.NoError(t, r.GeneratePath(ctx, ibc.NopRelayerExecReporter{}, "gaia-1", "osmosis-1", "ica"))
+	r.paths["ica"].chainA.connectionID = "connection-0"
+
+	err := r.CreateChannel(ctx, ibc.NopRelayerExecReporter{}, "ica", ibc.CreateChannelOptions{SourcePortName: "icacontroller", Order: ibc.Ordered})
+	require.ErrorContains(t, err, "source and destination ports are required")
+	require.Empty(t, f.cmds)
+
+	require.NoError(t, r.CreateChannel(ctx, ibc.NopRelayerExecReporter{}, "ica", ibc.CreateChannelOptions{
+		SourcePortName: "icacontroller",
+		DestPortName:   "icahost",
+		Order:          ibc.Ordered,
+		Version:        "ics27-1",
+	}))
+	require.Equal(t, [][]string{{
+		"hermes", "--config", "/home/hermes/.hermes/config.toml", "--json", "create", "channel",
+		"--order", "ordered", "--a-chain", "gaia-1", "--a-port", "icacontroller", "--b-port", "icahost",
+		"--a-connection", "connection-0", "--channel-version", "ics27-1",
+	}}, f.cmds)
+}
+
+func TestErrPathNotFound(t *testing.T) {
+	ctx := context.Background()
+
+	f := newFakeExecutor(t, nil)
+	r := f.relayer()
+
+	_, _, err := r.PathPorts("p")
+	require.ErrorIs(t, err, ErrPathNotFound)
+	require.ErrorContains(t, err, "path not found: p")
+	require.ErrorIs(t, r.CreateClients(ctx, ibc.NopRelayerExecReporter{}, "p", ibc.DefaultClientOpts()), ErrPathNotFound)
+	require.ErrorIs(t, r.CreateConnections(ctx, ibc.NopRelayerExecReporter{}, "p"), ErrPathNotFound)
+	require.ErrorIs(t, r.CreateChannel(ctx, ibc.NopRelayerExecReporter{}, "p", ibc.DefaultChannelOpts()), ErrPathNotFound)
+	require.ErrorIs(t, r.UpdateClients(ctx, ibc.NopRelayerExecReporter{}, "p"), ErrPathNotFound)
+	require.ErrorIs(t, r.Flush(ctx, ibc.NopRelayerExecReporter{}, "p", "channel-0"), ErrPathNotFound)
+	require.Empty(t, f.cmds)
+
+	require.NoError(t, r.GeneratePath(ctx, ibc.NopRelayerExecReporter{}, "gaia-1", "osmosis-1", "p"))
+	_, _, err = r.PathPorts("p")
+	require.NotErrorIs(t, err, ErrPathNotFound)
+}
+
+func TestPathPorts(t *testing.T) {
+	ctx := context.Background()
+
+	f := newFakeExecutor(t, map[string][]ibc.RelayerExecResult{
+		"create channel": {cannedOutput(t, "empty_object.json")},
+		"clear packets":  {cannedOutput(t, "clear_packets.txt")},
+	})
+	r := f.relayer()
+	require.NoError(t, r.GeneratePath(ctx, ibc.NopRelayerExecReporter{}, "gaia-1", "osmosis-1", "ica"))
+
+	_, _, err := r.PathPorts("missing")
+	require.ErrorIs(t, err, ErrPathNotFound)
+	_, _, err = r.PathPorts("ica")
+	require.ErrorContains(t, err, "path ica has no channel")
+
+	controllerPort := "icacontroller-cosmos1hj5fveer5cjtn4wd6wstzugjfdxzl0xpxvjjvr"
+	require.NoError(t, r.CreateChannel(ctx, ibc.NopRelayerExecReporter{}, "ica", ibc.CreateChannelOptions{
+		SourcePortName: controllerPort,
+		DestPortName:   "icahost",
+		Order:          ibc.Ordered,
+		Version:        "ics27-1",
+	}))
+
+	srcPort, dstPort, err := r.PathPorts("ica")
+	require.NoError(t, err)
+	require.Equal(t, controllerPort, srcPort)
+	require.Equal(t, "icahost", dstPort)
+
+	require.NoError(t, r.Flush(ctx, ibc.NopRelayerExecReporter{}, "ica", "channel-0"))
+	require.Equal(t, []string{
+		"hermes", "--config", "/home/hermes/.hermes/config.toml", "clear", "packets",
+		"--chain", "gaia-1", "--channel", "channel-0", "--port", controllerPort,
+	}, f.cmds[len(f.cmds)-1])
+}
+
+func TestUseExistingClients(t *testing.T) {
+	ctx := context.Background()
+
+	f := newFakeExecutor(t, map[string][]ibc.RelayerExecResult{
+		"query clients --host-chain gaia-1":    {cannedOutput(t, "clients_gaia.json")},
+		"query clients --host-chain osmosis-1": {cannedOutput(t, "clients_osmosis.json")},
+		"create connection":                    {cannedOutput(t, "create_connection.json")},
+	})
+	r := f.relayer()
+	require.NoError(t, r.GeneratePath(ctx, ibc.NopRelayerExecReporter{}, "gaia-1", "osmosis-1", "p"))
+
+	require.ErrorContains(t, r.UseExistingClients(ctx, ibc.NopRelayerExecReporter{}, "p", "07-tendermint-9", "07-tendermint-5"), "client 07-tendermint-9 not found on gaia-1")
+	require.ErrorContains(t, r.UseExistingClients(ctx, ibc.NopRelayerExecReporter{}, "p", "07-tendermint-3", "07-tendermint-6"), "tracks juno-1, not gaia-1")
+
+	require.NoError(t, r.UseExistingClients(ctx, ibc.NopRelayerExecReporter{}, "p", "07-tendermint-3", "07-tendermint-5"))
+	require.NoError(t, r.CreateConnections(ctx, ibc.NopRelayerExecReporter{}, "p"))
+	require.Equal(t, []string{
+		"hermes", "--config", "/home/hermes/.hermes/config.toml", "--json", "create", "connection",
+		"--a-chain", "gaia-1", "--a-client", "07-tendermint-3", "--b-client", "07-tendermint-5",
+	}, f.cmds[len(f.cmds)-1])
+	require.Equal(t, "connection-0", r.paths["p"].chainA.connectionID)
+}
+
+func TestCreateClientsTrustedHeight(t *testing.T) {
+	ctx := context.Background()
+
+	f := newFakeExecutor(t, map[string][]ibc.RelayerExecResult{
+		"query chain status": {cannedOutput(t, "chain_status.json", 50)},
+		"create client":      {cannedOutput(t, "create_client.json", "07-tendermint-0")},
+	})
+	r := f.relayer()
+	for _, chainID := range []string{"gaia-1", "osmosis-1"} {
+		_, err := r.configContent(ibc.ChainConfig{ChainID: chainID, Denom: "stake", GasPrices: "0.01stake"}, "relayer", "http://rpc:26657", "grpc:9090")
+		require.NoError(t, err)
+	}
+	require.NoError(t, r.GeneratePath(ctx, ibc.NopRelayerExecReporter{}, "gaia-1", "osmosis-1", "p"))
+
+	require.NoError(t, r.CreateClients(ctx, ibc.NopRelayerExecReporter{}, "p", ibc.CreateClientOptions{TrustingPeriod: "0", TrustedHeight: 10}))
+	require.Len(t, f.cmds, 4)
+	require.Equal(t, []string{
+		"hermes", "--config", "/home/hermes/.hermes/config.toml", "--json", "create", "client",
+		"--host-chain", "gaia-1", "--reference-chain", "osmosis-1", "--trusted-height", "10",
+	}, f.cmds[2])
+	require.Equal(t, []string{
+		"hermes", "--config", "/home/hermes/.hermes/config.toml", "--json", "create", "client",
+		"--host-chain", "osmosis-1", "--reference-chain", "gaia-1", "--trusted-height", "10",
+	}, f.cmds[3])
+
+	// A height the reference chain has not reached yet is rejected before any client is created.
+	f.reset()
+	err := r.CreateClients(ctx, ibc.NopRelayerExecReporter{}, "p", ibc.CreateClientOptions{TrustingPeriod: "0", TrustedHeight: 51})
+	require.ErrorContains(t, err, "trusted height 51 is ahead of the latest height 50 of gaia-1")
+	require.Len(t, f.cmds, 1)
+}
+
+func TestCreateClientsClientType(t *testing.T) {
+	ctx := context.Background()
+
+	f := newFakeExecutor(t, map[string][]ibc.RelayerExecResult{
+		"create client": {cannedOutput(t, "create_client.json", "07-tendermint-4")},
+	})
+	r := f.relayer()
+	require.NoError(t, r.GeneratePath(ctx, ibc.NopRelayerExecReporter{}, "gaia-1", "osmosis-1", "p"))
+
+	opts := ibc.CreateClientOptions{TrustingPeriod: "0", ClientType: "07-tendermint"}
+	require.NoError(t, r.CreateClients(ctx, ibc.NopRelayerExecReporter{}, "p", opts))
+	require.Regexp(t, `^07-tendermint-\d+$`, r.paths["p"].chainA.clientID)
+	require.Regexp(t, `^07-tendermint-\d+$`, r.paths["p"].chainB.clientID)
+
+	f.respond("create client", cannedOutput(t, "create_client.json", "06-solomachine-0"))
+	require.ErrorContains(t, r.CreateClients(ctx, ibc.NopRelayerExecReporter{}, "p", opts), "created client 06-solomachine-0 is not of the requested type 07-tendermint")
+
+	f.reset()
+	err := r.CreateClients(ctx, ibc.NopRelayerExecReporter{}, "p", ibc.CreateClientOptions{TrustingPeriod: "0", ClientType: "08-wasm"})
+	require.ErrorIs(t, err, relayer.ErrUnsupportedCapability)
+	require.Empty(t, f.cmds)
+}
+
+func TestParseConnectionHandshake(t *testing.T) {
+	stdout := readTestdata(t, "create_connection.json")
+	handshake, err := parseConnectionHandshake(stdout, "gaia-1")
+	require.NoError(t, err)
+	require.Equal(t, ibc.ConnectionHandshake{
+		SrcConnID:   "connection-0",
+		DstConnID:   "connection-2",
+		SrcClientID: "07-tendermint-0",
+		DstClientID: "07-tendermint-1",
+	}, handshake)
+
+	_, err = parseConnectionHandshake(stdout, "osmosis-1")
+	require.ErrorContains(t, err, "connection created with gaia-1 as a-side, expected osmosis-1")
+
+	_, err = parseConnectionHandshake([]byte("garbage"), "gaia-1")
+	require.ErrorIs(t, err, ErrParseOutput)
+}
+
+func TestSetASide(t *testing.T) {
+	ctx := context.Background()
+
+	f := newFakeExecutor(t, map[string][]ibc.RelayerExecResult{
+		"create client --host-chain gaia-1":    {cannedOutput(t, "create_client.json", "07-tendermint-0")},
+		"create client --host-chain osmosis-1": {cannedOutput(t, "create_client.json", "07-tendermint-1")},
+		"create connection":                    {cannedOutput(t, "create_connection_osmosis.json")},
+	})
+	r := f.relayer()
+	require.NoError(t, r.GeneratePath(ctx, ibc.NopRelayerExecReporter{}, "gaia-1", "osmosis-1", "p"))
+
+	require.ErrorContains(t, r.SetASide("p", "juno-1"), "chain juno-1 is not on path p")
+	require.ErrorIs(t, r.SetASide("q", "gaia-1"), ErrPathNotFound)
+	require.NoError(t, r.SetASide("p", "osmosis-1"))
+	a, b, err := r.PathSides("p")
+	require.NoError(t, err)
+	require.Equal(t, []string{"osmosis-1", "gaia-1"}, []string{a, b})
+
+	require.NoError(t, r.CreateClients(ctx, ibc.NopRelayerExecReporter{}, "p", ibc.CreateClientOptions{TrustingPeriod: "0"}))
+	require.Equal(t, []string{"create", "client", "--host-chain", "osmosis-1", "--reference-chain", "gaia-1"}, f.commands("")[0])
+	require.Equal(t, "07-tendermint-1", r.paths["p"].chainA.clientID)
+	require.Equal(t, "07-tendermint-0", r.paths["p"].chainB.clientID)
+
+	handshake, err := r.CreateConnectionsWithResult(ctx, ibc.NopRelayerExecReporter{}, "p")
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"create", "connection", "--a-chain", "osmosis-1", "--a-client", "07-tendermint-1", "--b-client", "07-tendermint-0"}}, f.commands("create connection"))
+	require.Equal(t, "connection-3", handshake.SrcConnID)
+	require.Equal(t, "connection-3", r.paths["p"].chainA.connectionID)
+
+	// Setting the current a-side is a no-op.
+	require.NoError(t, r.SetASide("p", "osmosis-1"))
+	require.Equal(t, "osmosis-1", r.paths["p"].chainA.chainID)
+}
+
+func TestRelayTimeouts(t *testing.T) {
+	ctx := context.Background()
+
+	// Relaying any of the pending packets would fail the test, as the fake executor has no response for it.
+	f := newFakeExecutor(t, map[string][]ibc.RelayerExecResult{
+		"query packet pending": {cannedOutput(t, "packet_pending.json")},
+	})
+	r := f.relayer()
+	require.NoError(t, r.GeneratePath(ctx, ibc.NopRelayerExecReporter{}, "gaia-1", "osmosis-1", "p"))
+	r.paths["p"].chainA.portID = "transfer"
+
+	_, err := r.RelayTimeouts(ctx, ibc.NopRelayerExecReporter{}, "p", "channel-0")
+	require.ErrorIs(t, err, relayer.ErrUnsupportedCapability)
+	require.Empty(t, f.cmds)
+
+	// The packets that have not timed out are still pending.
+	pending, err := r.pendingSequences(ctx, ibc.NopRelayerExecReporter{}, "gaia-1", "transfer", "channel-0")
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1, 2, 3, 4, 7, 8, 9}, pending)
+
+	_, err = r.RelayTimeouts(ctx, ibc.NopRelayerExecReporter{}, "missing", "channel-0")
+	require.ErrorIs(t, err, ErrPathNotFound)
+}
+
+func TestParseKeysListOutput(t *testing.T) {
+	wallets, err := parseKeysListOutput(readTestdata(t, "keys_list.json"))
+	require.NoError(t, err)
+	require.Len(t, wallets, 2)
+	require.Equal(t, "faucet", wallets[0].KeyName())
+	require.Equal(t, "cosmos1hj5fveer5cjtn4wd6wstzugjfdxzl0xpxvjjvr", wallets[0].FormattedAddress())
+	require.Equal(t, "relayer", wallets[1].KeyName())
+	require.Equal(t, "cosmos1czklnpzwaq3hfxtv6ne4vas2p9m5q3p3fgkz8e", wallets[1].FormattedAddress())
+
+	_, err = parseKeysListOutput([]byte("garbage"))
+	require.ErrorIs(t, err, ErrParseOutput)
+}
+
+func TestVersion(t *testing.T) {
+	ctx := context.Background()
+
+	f := newFakeExecutor(t, map[string][]ibc.RelayerExecResult{
+		"hermes version": {cannedOutput(t, "version.txt")},
+	})
+	r := f.relayer()
+
+	version, err := r.Version(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "1.6.0+4b5b34e", version)
+	require.Equal(t, [][]string{{"hermes", "version"}}, f.cmds)
+
+	f.respond("hermes version", ibc.RelayerExecResult{Stdout: []byte("garbage")})
+	_, err = r.Version(ctx)
+	require.ErrorIs(t, err, ErrParseOutput)
+
+	f.respond("hermes version", ibc.RelayerExecResult{ExitCode: 127, Stderr: []byte("sh: hermes: not found"), Err: fmt.Errorf("exit code 127")})
+	_, err = r.Version(ctx)
+	require.ErrorIs(t, err, ErrHermesCommand)
+}
+
+func TestCapability(t *testing.T) {
+	r := &Relayer{}
+	require.False(t, r.Capability(relayer.TimestampTimeout))
+	require.True(t, r.Capability(relayer.HeightTimeout))
+	require.True(t, r.Capability(relayer.Flush))
+}
+
+func TestCheckTransferOptions(t *testing.T) {
+	r := &Relayer{}
+	require.NoError(t, r.CheckTransferOptions(ibc.TransferOptions{}))
+	require.NoError(t, r.CheckTransferOptions(ibc.TransferOptions{Timeout: &ibc.IBCTimeout{Height: 10}}))
+
+	err := r.CheckTransferOptions(ibc.TransferOptions{Timeout: &ibc.IBCTimeout{NanoSeconds: uint64(time.Minute)}})
+	require.ErrorIs(t, err, relayer.ErrUnsupportedCapability)
+	require.ErrorContains(t, err, "TimestampTimeout")
+
+	// The transfer is rejected before it is sent from the chain.
+	_, err = ibc.SendIBCTransfer(context.Background(), r, struct{ ibc.Chain }{}, "channel-0", "user", ibc.WalletAmount{},
+		ibc.TransferOptions{Timeout: &ibc.IBCTimeout{NanoSeconds: uint64(time.Minute)}})
+	require.ErrorIs(t, err, relayer.ErrUnsupportedCapability)
+}
+
+func TestWaitForBlocks(t *testing.T) {
+	ctx := context.Background()
+
+	var heights []ibc.RelayerExecResult
+	for _, height := range []int{10, 10, 11, 13} {
+		heights = append(heights, cannedOutput(t, "chain_status.json", height))
+	}
+	f := newFakeExecutor(t, map[string][]ibc.RelayerExecResult{"query chain status": heights})
+	r := f.relayer()
+	r.chainConfigs = []ChainConfig{{cfg: ibc.ChainConfig{ChainID: "gaia-1"}}}
+	r.SetMaxBlockTime(time.Second)
+
+	require.NoError(t, r.WaitForBlocks(ctx, ibc.NopRelayerExecReporter{}, "gaia-1", 3))
+	require.Len(t, f.cmds, 4)
+
+	// The chain is stuck at height 13.
+	r.SetMaxBlockTime(100 * time.Millisecond)
+	err := r.WaitForBlocks(ctx, ibc.NopRelayerExecReporter{}, "gaia-1", 1)
+	require.ErrorContains(t, err, "chain gaia-1 stopped producing blocks at height 13")
+
+	err = r.WaitForBlocks(ctx, ibc.NopRelayerExecReporter{}, "osmosis-1", 1)
+	require.ErrorContains(t, err, "chain osmosis-1 is not configured")
+}
+
+func TestQueryLatestHeightUnknownChain(t *testing.T) {
+	r := &Relayer{}
+	_, err := r.QueryLatestHeight(context.Background(), ibc.NopRelayerExecReporter{}, "gaia-1")
+	require.ErrorContains(t, err, "not configured")
+}
+
+func TestAddChainAlreadyConfigured(t *testing.T) {
+	r := &Relayer{}
+	r.chainConfigs = []ChainConfig{{cfg: ibc.ChainConfig{ChainID: "gaia-1"}}}
+	err := r.AddChain(context.Background(), ibc.NopRelayerExecReporter{}, ibc.ChainConfig{ChainID: "gaia-1"}, "relayer", "http://gaia-1:26657", "gaia-1:9090", "")
+	require.ErrorContains(t, err, "chain gaia-1 is already configured")
+	require.Equal(t, []string{"gaia-1"}, r.ConfiguredChains())
+}
+
+func TestCreateChannelOnPath(t *testing.T) {
+	ctx := context.Background()
+
+	f := newFakeExecutor(t, map[string][]ibc.RelayerExecResult{
+		"query connections": {cannedOutput(t, "connections_tryopen.json")},
+		"create channel":    {cannedOutput(t, "empty_object.json")},
+	})
+	r := f.relayer()
+	require.NoError(t, r.GeneratePath(ctx, ibc.NopRelayerExecReporter{}, "gaia-1", "osmosis-1", "p"))
+	opts := ibc.CreateChannelOptions{SourcePortName: "icacontroller-owner", DestPortName: "icahost", Order: ibc.Ordered, Version: "ics27-1"}
+
+	require.ErrorContains(t, r.CreateChannelOnPath(ctx, ibc.NopRelayerExecReporter{}, "p", opts), "path p has no connection")
+	r.paths["p"].chainA.connectionID = "connection-0"
+
+	f.reset()
+	err := r.CreateChannelOnPath(ctx, ibc.NopRelayerExecReporter{}, "p", opts)
+	require.ErrorContains(t, err, "connection connection-0 of path p is not open on gaia-1 (TryOpen)")
+	require.Len(t, f.cmds, 1)
+
+	f.respond("query connections", cannedOutput(t, "connections_open.json"))
+	f.reset()
+	require.NoError(t, r.CreateChannelOnPath(ctx, ibc.NopRelayerExecReporter{}, "p", opts))
+	require.Equal(t, [][]string{
+		{"query", "connections", "--chain", "gaia-1", "--verbose"},
+		{"create", "channel", "--order", "ordered", "--a-chain", "gaia-1", "--a-port", "icacontroller-owner", "--b-port", "icahost", "--a-connection", "connection-0", "--channel-version", "ics27-1"},
+	}, f.commands(""))
+}
+
+func TestCloseChannel(t *testing.T) {
+	ctx := context.Background()
+
+	f := newFakeExecutor(t, map[string][]ibc.RelayerExecResult{
+		"query channels":        {cannedOutput(t, "channels_ica_controller.json")},
+		"tx chan-close-init":    {cannedOutput(t, "chan_close_init.json")},
+		"tx chan-close-confirm": {cannedOutput(t, "chan_close_confirm.json")},
+	})
+	r := f.relayer()
+	require.NoError(t, r.GeneratePath(ctx, ibc.NopRelayerExecReporter{}, "gaia-1", "osmosis-1", "p"))
+	r.paths["p"].chainA.connectionID = "connection-0"
+	r.paths["p"].chainB.connectionID = "connection-3"
+
+	require.NoError(t, r.CloseChannel(ctx, ibc.NopRelayerExecReporter{}, "p", "channel-1"))
+	require.Equal(t, [][]string{
+		{"tx", "chan-close-init", "--dst-chain", "gaia-1", "--src-chain", "osmosis-1", "--dst-connection", "connection-0",
+			"--dst-port", "icacontroller-owner", "--src-port", "icahost", "--dst-channel", "channel-1", "--src-channel", "channel-9"},
+		{"tx", "chan-close-confirm", "--dst-chain", "osmosis-1", "--src-chain", "gaia-1", "--dst-connection", "connection-3",
+			"--dst-port", "icahost", "--src-port", "icacontroller-owner", "--dst-channel", "channel-9", "--src-channel", "channel-1"},
+	}, f.commands("tx"))
+
+	require.ErrorIs(t, r.CloseChannel(ctx, ibc.NopRelayerExecReporter{}, "p", "channel-2"), ibc.ErrChannelNotFound)
+
+	err := parseChannelClose([]byte(`{"result":{"CloseInitChannel":{"port_id":"transfer","channel_id":"channel-4"}},"status":"success"}`), "CloseInitChannel", "channel-1")
+	require.ErrorContains(t, err, "CloseInitChannel event for channel-4, expected channel-1")
+	err = parseChannelClose([]byte(`{"result":{"OpenInitChannel":{"port_id":"transfer","channel_id":"channel-1"}},"status":"success"}`), "CloseInitChannel", "channel-1")
+	require.ErrorIs(t, err, ErrParseOutput)
+}
+
+func TestKeyringDir(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	ctx := context.Background()
+	rep := ibc.NopRelayerExecReporter{}
+	cli, network := dockerutil.DockerSetup(t)
+
+	// The keyring is written by the hermes user, so the directory is removed on a best effort basis.
+	dir, err := os.MkdirTemp("", "hermes-keyring")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	require.NoError(t, os.Chmod(dir, 0o777))
+
+	cfg := ibc.ChainConfig{
+		Type:         "cosmos",
+		ChainID:      "gaia-1",
+		Bech32Prefix: "cosmos",
+		Denom:        "uatom",
+		GasPrices:    "0.01uatom",
+		CoinType:     "118",
+	}
+	const mnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+	var relayers []*Relayer
+	for _, name := range []string{"a", "b"} {
+		r := NewHermesRelayer(zap.NewNop(), t.Name()+name, cli, network, KeyringDir(dir))
+		require.NoError(t, r.AddChainConfiguration(ctx, rep, cfg, "relayer", "http://gaia-1:26657", "http://gaia-1:9090"))
+		relayers = append(relayers, r)
+	}
+
+	require.NoError(t, relayers[0].RestoreKey(ctx, rep, cfg, "relayer", mnemonic))
+	require.True(t, relayers[0].HasKey("gaia-1", "relayer"))
+
+	for _, r := range relayers {
+		keys, err := r.ListKeys(ctx, rep, "gaia-1")
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+		require.Equal(t, "relayer", keys[0].KeyName())
+	}
+}
+
+func TestLinkPathCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	f := newFakeExecutor(t, map[string][]ibc.RelayerExecResult{
+		"create client": {cannedOutput(t, "create_client.json", "07-tendermint-0")},
+	})
+	// The caller gives up while the first client is being created.
+	r := NewHermesRelayerWithExecutor(zap.NewNop(), t.Name(), func(ctx context.Context, cmd []string, env []string) ibc.RelayerExecResult {
+		cancel()
+		return f.exec(ctx, cmd, env)
+	})
+	require.NoError(t, r.GeneratePath(ctx, ibc.NopRelayerExecReporter{}, "gaia-1", "osmosis-1", "p"))
+
+	err := r.LinkPath(ctx, ibc.NopRelayerExecReporter{}, "p", ibc.DefaultChannelOpts(), ibc.DefaultClientOpts())
+	require.ErrorIs(t, err, context.Canceled)
+	cmds := f.commands("")
+	require.Len(t, cmds, 1)
+	require.Equal(t, []string{"create", "client", "--host-chain", "gaia-1", "--reference-chain", "osmosis-1"}, cmds[0][:6])
+}
+
+func TestHandshakeRetries(t *testing.T) {
+	ctx := context.Background()
+
+	failure := ibc.RelayerExecResult{Err: fmt.Errorf("exit code 1: rpc error: connection refused"), ExitCode: 1}
+	f := newFakeExecutor(t, map[string][]ibc.RelayerExecResult{
+		"create connection": {failure, cannedOutput(t, "create_connection.json")},
+	})
+	r := f.relayer()
+	require.NoError(t, r.GeneratePath(ctx, ibc.NopRelayerExecReporter{}, "gaia-1", "osmosis-1", "p"))
+	require.ErrorContains(t, r.SetHandshakeRetries(0, time.Millisecond), "invalid handshake attempts 0")
+
+	// A transient failure is retried.
+	require.NoError(t, r.SetHandshakeRetries(2, 10*time.Millisecond))
+	require.NoError(t, r.CreateConnections(ctx, ibc.NopRelayerExecReporter{}, "p"))
+	require.Len(t, f.cmds, 2)
+	require.Equal(t, "connection-0", r.paths["p"].chainA.connectionID)
+
+	// The last failure is returned once the attempts are exhausted.
+	f.respond("create connection", failure)
+	f.reset()
+	require.NoError(t, r.SetHandshakeRetries(3, 10*time.Millisecond))
+	err := r.CreateConnections(ctx, ibc.NopRelayerExecReporter{}, "p")
+	require.ErrorIs(t, err, ErrHermesCommand)
+	require.ErrorContains(t, err, "connection refused")
+	require.Len(t, f.cmds, 3)
+}
@@ -0,0 +1,179 @@
+package hermes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/pelletier/go-toml"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/relayer"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeExecutor answers the commands run by a hermes relayer from a table of canned results,
+// recording every command it is given.
+//
+// The table is keyed by the leading arguments of a command, after the hermes binary and its global flags,
+// e.g. "query channels" or "create client --host-chain gaia-1". A command is answered by the longest key it
+// starts with, so the empty key answers every command not matched otherwise. The results of a key are returned
+// in turn and the last one is repeated, so a test can script how a chain changes between queries.
+// A command matching no key fails the test, and a command answered with hangingResult blocks until its context is done.
+type fakeExecutor struct {
+	t *testing.T
+
+	mu        sync.Mutex
+	responses map[string][]ibc.RelayerExecResult
+	cmds      [][]string
+}
+
+// errHanging marks hangingResult.
+var errHanging = errors.New("hanging")
+
+// hangingResult answers a command that does not complete before its context is done, e.g. a query interrupted
+// by a deadline.
+var hangingResult = ibc.RelayerExecResult{Err: errHanging}
+
+func newFakeExecutor(t *testing.T, responses map[string][]ibc.RelayerExecResult) *fakeExecutor {
+	if responses == nil {
+		responses = map[string][]ibc.RelayerExecResult{}
+	}
+	return &fakeExecutor{t: t, responses: responses}
+}
+
+// relayer returns a hermes relayer running its commands with f.
+func (f *fakeExecutor) relayer(options ...relayer.RelayerOpt) *Relayer {
+	return NewHermesRelayerWithExecutor(zap.NewNop(), f.t.Name(), f.exec, options...)
+}
+
+// respond replaces the results of the commands starting with the given arguments.
+func (f *fakeExecutor) respond(prefix string, results ...ibc.RelayerExecResult) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.responses[prefix] = results
+}
+
+func (f *fakeExecutor) exec(ctx context.Context, cmd []string, _ []string) ibc.RelayerExecResult {
+	res := f.next(cmd)
+	if errors.Is(res.Err, errHanging) {
+		<-ctx.Done()
+		return ibc.RelayerExecResult{Err: ctx.Err(), ExitCode: 1}
+	}
+	return res
+}
+
+// next records cmd and returns the result it is answered with.
+func (f *fakeExecutor) next(cmd []string) ibc.RelayerExecResult {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.cmds = append(f.cmds, cmd)
+
+	key, ok := "", false
+	for prefix := range f.responses {
+		if hasArgsPrefix(hermesArgs(cmd), prefix) && (!ok || len(prefix) > len(key)) {
+			key, ok = prefix, true
+		}
+	}
+	if !ok {
+		f.t.Errorf("unexpected command: %s", strings.Join(cmd, " "))
+		return ibc.RelayerExecResult{Err: errors.New("unexpected command"), ExitCode: 1}
+	}
+	results := f.responses[key]
+	if len(results) > 1 {
+		f.responses[key] = results[1:]
+	}
+	return results[0]
+}
+
+// commands returns the arguments of the commands run so far that start with the given prefix,
+// without the hermes binary and its global flags.
+func (f *fakeExecutor) commands(prefix string) [][]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var cmds [][]string
+	for _, cmd := range f.cmds {
+		if args := hermesArgs(cmd); hasArgsPrefix(args, prefix) {
+			cmds = append(cmds, args)
+		}
+	}
+	return cmds
+}
+
+// reset forgets the commands run so far.
+func (f *fakeExecutor) reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.cmds = nil
+}
+
+// hermesArgs strips the hermes binary, its config flag and the --json flag from cmd.
+// Commands run without a config, e.g. "hermes version" or the telemetry scrape, are returned whole.
+func hermesArgs(cmd []string) []string {
+	if len(cmd) < 3 || cmd[1] != "--config" {
+		return cmd
+	}
+	args := cmd[3:]
+	if len(args) > 0 && args[0] == "--json" {
+		args = args[1:]
+	}
+	return args
+}
+
+func hasArgsPrefix(args []string, prefix string) bool {
+	words := strings.Fields(prefix)
+	return len(args) >= len(words) && slices.Equal(args[:len(words)], words)
+}
+
+// cannedOutput returns a successful result printing the hermes output stored in testdata/name.
+func cannedOutput(t *testing.T, name string, args ...any) ibc.RelayerExecResult {
+	t.Helper()
+	return ibc.RelayerExecResult{Stdout: readTestdata(t, name, args...)}
+}
+
+// readTestdata returns the content of testdata/name, used as a format string for args if any are given.
+func readTestdata(t *testing.T, name string, args ...any) []byte {
+	t.Helper()
+	bz, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	if len(args) > 0 {
+		bz = []byte(fmt.Sprintf(string(bz), args...))
+	}
+	return bz
+}
+
+// startRelayerCmd returns the command the commander builds to start the relayer.
+func startRelayerCmd(t *testing.T, c *commander, homeDir string, pathNames ...string) []string {
+	t.Helper()
+	cmd, err := c.StartRelayer(homeDir, pathNames...)
+	require.NoError(t, err)
+	return cmd
+}
+
+func mustMarshal(t *testing.T, v any) []byte {
+	t.Helper()
+	bz, err := toml.Marshal(v)
+	require.NoError(t, err)
+	return bz
+}
+
+// fakeHeaderClient returns the same header at every height.
+type fakeHeaderClient struct {
+	header cmttypes.Header
+}
+
+func (c fakeHeaderClient) Header(_ context.Context, height *int64) (*coretypes.ResultHeader, error) {
+	header := c.header
+	if header.Height == 0 {
+		header.Height = *height
+	}
+	return &coretypes.ResultHeader{Header: &header}, nil
+}
@@ -14,6 +14,13 @@ type CreateClientResult struct {
 	CreateClient CreateClient `json:"CreateClient"`
 }
 
+// KeysListResponse contains the keys restored for a chain, keyed by key name, as output by "keys list".
+type KeysListResponse struct {
+	Result map[string]struct {
+		Account string `json:"account"`
+	} `json:"result"`
+}
+
 // ConnectionResponse contains the minimum required values to extract the connection id from both sides.
 type ConnectionResponse struct {
 	Result ConnectionResult `json:"result"`
@@ -25,7 +32,13 @@ type ConnectionResult struct {
 }
 
 type ConnectionSide struct {
-	ConnectionID string `json:"connection_id"`
+	Chain        ChainRef `json:"chain"`
+	ClientID     string   `json:"client_id"`
+	ConnectionID string   `json:"connection_id"`
+}
+
+type ChainRef struct {
+	ID string `json:"id"`
 }
 
 // ChannelOutputResult contains the minimum required channel values.
@@ -93,3 +106,72 @@ type ClientResult struct {
 	ChainID  string `json:"chain_id"`
 	ClientID string `json:"client_id"`
 }
+
+// ChainStatusResult contains the latest height and timestamp of a chain as reported by hermes.
+type ChainStatusResult struct {
+	Result ChainStatus `json:"result"`
+}
+
+type ChainStatus struct {
+	Height    ChainHeight `json:"height"`
+	Timestamp string      `json:"timestamp"`
+}
+
+type ChainHeight struct {
+	RevisionNumber uint64 `json:"revision_number"`
+	RevisionHeight uint64 `json:"revision_height"`
+}
+
+// ClientStateResponse contains the trusting period and latest height of a client, as output by "query client state".
+type ClientStateResponse struct {
+	Result struct {
+		ChainID        string      `json:"chain_id"`
+		TrustingPeriod DelayPeriod `json:"trusting_period"`
+		LatestHeight   ChainHeight `json:"latest_height"`
+	} `json:"result"`
+}
+
+// ConsensusStateResponse contains the timestamp and commitment root of a client consensus state,
+// as output by "query client consensus".
+type ConsensusStateResponse struct {
+	Result struct {
+		Timestamp string `json:"timestamp"`
+		// Root is the hex encoded app hash of the tracked chain.
+		Root string `json:"root"`
+	} `json:"result"`
+}
+
+// PendingPacketsResponse contains the sequences of the packets pending on both ends of a channel,
+// as output by "query packet pending".
+type PendingPacketsResponse struct {
+	Result struct {
+		Src PendingPackets `json:"src"`
+		Dst PendingPackets `json:"dst"`
+	} `json:"result"`
+}
+
+type PendingPackets struct {
+	UnreceivedPackets []uint64 `json:"unreceived_packets"`
+	UnreceivedAcks    []uint64 `json:"unreceived_acks"`
+}
+
+// PacketSequencesResponse contains the sequences of the packet commitments or acknowledgements stored on a channel end,
+// and the height at which they were queried, as output by "query packet commitments" and "query packet acks".
+type PacketSequencesResponse struct {
+	Result struct {
+		Height ChainHeight `json:"height"`
+		Seqs   []uint64    `json:"seqs"`
+	} `json:"result"`
+}
+
+// UnreceivedPacketsResponse contains the sequences of the packets committed on the counterparty that have not been
+// received on a channel end, as output by "query packet unreceived-packets".
+type UnreceivedPacketsResponse struct {
+	Result []uint64 `json:"result"`
+}
+
+// ChannelCloseResponse contains the event emitted by "tx chan-close-init" or "tx chan-close-confirm",
+// keyed by its type, e.g. "CloseInitChannel".
+type ChannelCloseResponse struct {
+	Result map[string]ChannelAndPortId `json:"result"`
+}
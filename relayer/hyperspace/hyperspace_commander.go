@@ -12,6 +12,7 @@ import (
 	"github.com/pelletier/go-toml/v2"
 	"github.com/strangelove-ventures/interchaintest/v8/chain/polkadot"
 	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/relayer"
 	"go.uber.org/zap"
 )
 
@@ -41,25 +42,25 @@ func (hyperspaceCommander) DockerUser() string {
 	return "1000:1000" // docker run -it --rm --entrypoint echo ghcr.io/cosmos/relayer "$(id -u):$(id -g)"
 }
 
-func (c *hyperspaceCommander) AddChainConfiguration(containerFilePath, homeDir string) []string {
+func (c *hyperspaceCommander) AddChainConfiguration(containerFilePath, homeDir string) ([]string, error) {
 	fmt.Println("[hyperspace] AddChainConfiguration ", containerFilePath, homeDir)
 	//c.chainConfigPaths = append(c.chainConfigPaths, containerFilePath)
 	return []string{
 		"hyperspace",
 		"-h",
-	}
+	}, nil
 }
 
 // Hyperspace doesn't not have this functionality
-func (hyperspaceCommander) AddKey(chainID, keyName, coinType, homeDir string) []string {
-	panic("[AddKey] Do not call me")
+func (hyperspaceCommander) AddKey(chainID, keyName, coinType, homeDir string) ([]string, error) {
+	return nil, fmt.Errorf("[AddKey] %w", relayer.ErrUnsupportedCapability)
 }
 
-func (c *hyperspaceCommander) CreateChannel(pathName string, opts ibc.CreateChannelOptions, homeDir string) []string {
+func (c *hyperspaceCommander) CreateChannel(pathName string, opts ibc.CreateChannelOptions, homeDir string) ([]string, error) {
 	fmt.Println("[hyperspace] CreateChannel", pathName, homeDir)
 	_, ok := c.paths[pathName]
 	if !ok {
-		panic(fmt.Sprintf("path %s not found", pathName))
+		return nil, fmt.Errorf("path %s not found", pathName)
 	}
 	return []string{
 		"hyperspace",
@@ -76,14 +77,14 @@ func (c *hyperspaceCommander) CreateChannel(pathName string, opts ibc.CreateChan
 		"unordered",
 		"--version",
 		opts.Version,
-	}
+	}, nil
 }
 
-func (c *hyperspaceCommander) CreateClients(pathName string, opts ibc.CreateClientOptions, homeDir string) []string {
+func (c *hyperspaceCommander) CreateClients(pathName string, opts ibc.CreateClientOptions, homeDir string) ([]string, error) {
 	fmt.Println("[hyperspace] CreateClients", pathName, opts, homeDir)
 	_, ok := c.paths[pathName]
 	if !ok {
-		panic(fmt.Sprintf("path %s not found", pathName))
+		return nil, fmt.Errorf("path %s not found", pathName)
 	}
 	return []string{
 		"hyperspace",
@@ -98,14 +99,14 @@ func (c *hyperspaceCommander) CreateClients(pathName string, opts ibc.CreateClie
 		"transfer",
 		"--order",
 		"unordered",
-	}
+	}, nil
 }
 
-func (c *hyperspaceCommander) CreateConnections(pathName, homeDir string) []string {
+func (c *hyperspaceCommander) CreateConnections(pathName, homeDir string) ([]string, error) {
 	fmt.Println("[hyperspace] CreateConnections", pathName, homeDir)
 	_, ok := c.paths[pathName]
 	if !ok {
-		panic(fmt.Sprintf("path %s not found", pathName))
+		return nil, fmt.Errorf("path %s not found", pathName)
 	}
 	return []string{
 		"hyperspace",
@@ -118,7 +119,7 @@ func (c *hyperspaceCommander) CreateConnections(pathName, homeDir string) []stri
 		path.Join(homeDir, "core.config"),
 		"--delay-period",
 		"1",
-	}
+	}, nil
 }
 
 // Hyperspace doesn't not have this functionality
@@ -132,7 +133,7 @@ func (hyperspaceCommander) FlushPackets(pathName, channelID, homeDir string) []s
 }
 
 // GeneratePath establishes an in memory path representation. The concept does not exist in hyperspace.
-func (c *hyperspaceCommander) GeneratePath(srcChainID, dstChainID, pathName, homeDir string) []string {
+func (c *hyperspaceCommander) GeneratePath(srcChainID, dstChainID, pathName, homeDir string) ([]string, error) {
 	if c.paths == nil {
 		c.paths = map[string]*pathConfiguration{}
 	}
@@ -144,69 +145,69 @@ func (c *hyperspaceCommander) GeneratePath(srcChainID, dstChainID, pathName, hom
 			chainID: dstChainID,
 		},
 	}
-	return []string{"true"}
+	return []string{"true"}, nil
 }
 
 // Hyperspace does not have paths, just two configs
-func (hyperspaceCommander) UpdatePath(pathName, homeDir string, filter ibc.ChannelFilter) []string {
-	panic("[UpdatePath] Do not call me")
+func (hyperspaceCommander) UpdatePath(pathName, homeDir string, filter ibc.ChannelFilter) ([]string, error) {
+	return nil, fmt.Errorf("[UpdatePath] %w", relayer.ErrUnsupportedCapability)
 
 }
 
 // Prints chain config which is populated by hyperspace
 // Ideally, there should be a command from hyperspace to get this output
-func (hyperspaceCommander) GetChannels(chainID, homeDir string) []string {
+func (hyperspaceCommander) GetChannels(chainID, homeDir string) ([]string, error) {
 	fmt.Println("[hyperspace] Get Channels")
 	configFilePath := path.Join(homeDir, chainID+".config")
 	return []string{
 		"cat",
 		configFilePath,
-	}
+	}, nil
 }
 
 // Prints chain config which is populated by hyperspace
 // Ideally, there should be a command from hyperspace to get this output
-func (hyperspaceCommander) GetConnections(chainID, homeDir string) []string {
+func (hyperspaceCommander) GetConnections(chainID, homeDir string) ([]string, error) {
 	fmt.Println("[hyperspace] Get Connections")
 	configFilePath := path.Join(homeDir, chainID+".config")
 	return []string{
 		"cat",
 		configFilePath,
-	}
+	}, nil
 }
 
 // Prints chain config which is populated by hyperspace
 // Ideally, there should be a command from hyperspace to get this output
-func (hyperspaceCommander) GetClients(chainID, homeDir string) []string {
+func (hyperspaceCommander) GetClients(chainID, homeDir string) ([]string, error) {
 	fmt.Println("[hyperspace] Get Clients")
 	configFilePath := path.Join(homeDir, chainID+".config")
 	return []string{
 		"cat",
 		configFilePath,
-	}
+	}, nil
 }
 
 // Hyperspace does not have link cmd, call create clients, create connection, and create channel
-func (hyperspaceCommander) LinkPath(pathName, homeDir string, channelOpts ibc.CreateChannelOptions, clientOpt ibc.CreateClientOptions) []string {
-	panic("[LinkPath] Do not use me")
+func (hyperspaceCommander) LinkPath(pathName, homeDir string, channelOpts ibc.CreateChannelOptions, clientOpt ibc.CreateClientOptions) ([]string, error) {
+	return nil, fmt.Errorf("[LinkPath] %w", relayer.ErrUnsupportedCapability)
 }
 
 // There is no hyperspace call to restore the key, so this can't return an executable.
 // HyperspaceRelayer's RestoreKey will restore the key in the chain's config file
-func (hyperspaceCommander) RestoreKey(chainID, bech32Prefix, coinType, mnemonic, homeDir string) []string {
-	panic("[RestoreKey] Do not use me")
+func (hyperspaceCommander) RestoreKey(chainID, bech32Prefix, coinType, mnemonic, homeDir string) ([]string, error) {
+	return nil, fmt.Errorf("[RestoreKey] %w", relayer.ErrUnsupportedCapability)
 }
 
 // hyperspace can only start 1 path
-func (c *hyperspaceCommander) StartRelayer(homeDir string, pathNames ...string) []string {
+func (c *hyperspaceCommander) StartRelayer(homeDir string, pathNames ...string) ([]string, error) {
 	fmt.Println("[hyperspace] StartRelayer", homeDir, pathNames)
 	if len(pathNames) != 1 {
-		panic("Hyperspace's StartRelayer list of paths can only have 1 path")
+		return nil, fmt.Errorf("hyperspace's StartRelayer list of paths can only have 1 path: %w", relayer.ErrUnsupportedCapability)
 	}
 	pathName := pathNames[0]
 	_, ok := c.paths[pathName]
 	if !ok {
-		panic(fmt.Sprintf("path %s not found", pathName))
+		return nil, fmt.Errorf("path %s not found", pathName)
 	}
 	return []string{
 		"hyperspace",
@@ -217,12 +218,12 @@ func (c *hyperspaceCommander) StartRelayer(homeDir string, pathNames ...string)
 		configPath(homeDir, c.paths[pathName].chainB.chainID),
 		"--config-core",
 		path.Join(homeDir, "core.config"),
-	}
+	}, nil
 }
 
 // Hyperspace doesn't not have this functionality
-func (hyperspaceCommander) UpdateClients(pathName, homeDir string) []string {
-	panic("[UpdateClients] Do not use me")
+func (hyperspaceCommander) UpdateClients(pathName, homeDir string) ([]string, error) {
+	return nil, fmt.Errorf("[UpdateClients] %w", relayer.ErrUnsupportedCapability)
 }
 
 func (hyperspaceCommander) ConfigContent(ctx context.Context, cfg ibc.ChainConfig, keyName, rpcAddr, grpcAddr string) ([]byte, error) {
@@ -366,8 +367,8 @@ func (hyperspaceCommander) CreateWallet(keyName, address, mnemonic string) ibc.W
 	return NewWallet("", kp.Address, mnemonic)
 }
 
-func (hyperspaceCommander) Flush(pathName, channelID, homeDir string) []string {
-	panic("flush implemented in hyperspace not the commander")
+func (hyperspaceCommander) Flush(pathName, channelID, homeDir string) ([]string, error) {
+	return nil, fmt.Errorf("flush is implemented in hyperspace, not the commander: %w", relayer.ErrUnsupportedCapability)
 }
 
 func configPath(homeDir, chainID string) string {
@@ -16,7 +16,7 @@ import (
 
 var _ ibc.Relayer = &HyperspaceRelayer{}
 
-// ******* DockerRelayer methods that will panic in hyperspace commander, no overrides yet *******
+// ******* DockerRelayer methods that return ErrUnsupportedCapability from the hyperspace commander, no overrides yet *******
 // FlushAcknowledgements() - no hyperspace implementation yet
 // FlushPackets() - no hypersapce implementation yet
 // UpdatePath() - hyperspace doesn't understand paths, may not be needed.
@@ -1,6 +1,10 @@
 package relayer
 
 import (
+	"os"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
 	"github.com/strangelove-ventures/interchaintest/v8/ibc"
 )
 
@@ -32,10 +36,157 @@ func HomeDir(homeDir string) RelayerOpt {
 	}
 }
 
+// HomeDirMode overrides the permission mode of the relayer home directory, which defaults to 0700.
+func HomeDirMode(mode os.FileMode) RelayerOpt {
+	return func(r *DockerRelayer) {
+		r.homeDirMode = mode
+	}
+}
+
+// DockerLabels attaches the given labels to the docker containers and volumes created for the relayer,
+// in addition to the labels interchaintest requires for cleanup.
+func DockerLabels(labels map[string]string) RelayerOpt {
+	return func(r *DockerRelayer) {
+		r.labels = labels
+	}
+}
+
+// PullPolicy determines whether the relayer image is pulled when the relayer is created.
+type PullPolicy int
+
+const (
+	// PullIfNotPresent pulls the image only if it is not already present locally. This is the default.
+	PullIfNotPresent PullPolicy = iota
+	// PullAlways pulls the image every time, picking up any updates to a mutable tag.
+	PullAlways
+	// PullNever never pulls the image; the relayer fails to start with ErrImageNotPresent if it is not present locally.
+	// This is useful for offline or air-gapped environments.
+	PullNever
+)
+
+// ExtraHosts adds "host:ip" entries to /etc/hosts in the relayer containers,
+// e.g. to reach chain endpoints by hostnames that docker's internal DNS cannot resolve.
+func ExtraHosts(hosts ...string) RelayerOpt {
+	return func(r *DockerRelayer) {
+		r.extraHosts = hosts
+	}
+}
+
+// DNS overrides the DNS servers used by the relayer containers, which default to docker's defaults.
+func DNS(servers ...string) RelayerOpt {
+	return func(r *DockerRelayer) {
+		r.dns = servers
+	}
+}
+
+// Env sets environment variables, in "KEY=value" form, in every relayer container,
+// e.g. "RUST_LOG=debug" or "RUST_BACKTRACE=1" for hermes. No variables are set by default.
+// Variables passed to an individual Exec take precedence.
+func Env(vars ...string) RelayerOpt {
+	return func(r *DockerRelayer) {
+		r.env = vars
+	}
+}
+
+// RestartOnFailure makes docker restart the relayer process started through StartRelayer
+// when it exits with a non-zero code, up to maxRetries times. By default, the relayer is not restarted.
+// Combined with StartupCheck, a relayer that keeps failing on startup is reported rather than restarted indefinitely.
+func RestartOnFailure(maxRetries int) RelayerOpt {
+	return func(r *DockerRelayer) {
+		r.restartPolicy = container.RestartPolicy{Name: "on-failure", MaximumRetryCount: maxRetries}
+	}
+}
+
+// LogDriver sets the docker logging driver of the relayer process started through StartRelayer, e.g. "json-file",
+// and its options, e.g. {"max-size": "100m", "max-file": "5"}, so that the logs of long runs are kept.
+// By default, the driver configured for the docker daemon is used.
+func LogDriver(driver string, options map[string]string) RelayerOpt {
+	return func(r *DockerRelayer) {
+		r.logConfig = container.LogConfig{Type: driver, Config: options}
+	}
+}
+
+// PreserveHomeDir copies the relayer home directory, including its config and keys, into a subdirectory of
+// artifactsDir when the docker resources of the test are cleaned up, before the home directory volume is removed,
+// for post-mortem debugging. The subdirectory is named after the relayer and its volume.
+func PreserveHomeDir(artifactsDir string) RelayerOpt {
+	return func(r *DockerRelayer) {
+		r.artifactsDir = artifactsDir
+	}
+}
+
+// StartupCheck makes StartRelayer watch the relayer process for the given duration after starting it,
+// and fail with ErrStartupFailed and the relayer logs as soon as the process exits or is restarted repeatedly,
+// e.g. due to an invalid config. By default, StartRelayer returns as soon as the process is started.
+func StartupCheck(window time.Duration) RelayerOpt {
+	return func(r *DockerRelayer) {
+		r.startupCheck = window
+	}
+}
+
+// HostMount mounts a file or directory of the host into every relayer container at containerPath,
+// e.g. to provide custom CA certificates or pre-generated keys. The host path must exist when the relayer
+// is created; a relative path is resolved against the working directory. It can be given multiple times.
+func HostMount(hostPath, containerPath string, readOnly bool) RelayerOpt {
+	return func(r *DockerRelayer) {
+		r.hostMounts = append(r.hostMounts, hostMount{hostPath: hostPath, containerPath: containerPath, readOnly: readOnly})
+	}
+}
+
+// MemoryLimit limits the memory available to the relayer containers, in bytes. Memory is unlimited by default.
+func MemoryLimit(bytes int64) RelayerOpt {
+	return func(r *DockerRelayer) {
+		r.resources.Memory = bytes
+	}
+}
+
+// CPULimit limits the relayer containers to the given number of CPUs, e.g. 1.5. CPU is unlimited by default.
+func CPULimit(cpus float64) RelayerOpt {
+	return func(r *DockerRelayer) {
+		r.resources.NanoCPUs = int64(cpus * 1e9)
+	}
+}
+
+// ImagePullPolicy overrides when the relayer image should be pulled on startup.
+func ImagePullPolicy(policy PullPolicy) RelayerOpt {
+	return func(r *DockerRelayer) {
+		r.pullPolicy = policy
+	}
+}
+
 // ImagePull overrides whether the relayer image should be pulled on startup.
+// ImagePull(true) is equivalent to ImagePullPolicy(PullAlways), and ImagePull(false) to ImagePullPolicy(PullNever).
 func ImagePull(pull bool) RelayerOpt {
+	if pull {
+		return ImagePullPolicy(PullAlways)
+	}
+	return ImagePullPolicy(PullNever)
+}
+
+// OutputMode determines what happens to the stdout and stderr of relayer containers.
+type OutputMode int
+
+const (
+	// OutputCapture collects the output once the container exits. This is the default.
+	OutputCapture OutputMode = iota
+	// OutputStream also writes the output to the relayer's logger as it is produced.
+	OutputStream
+	// OutputDiscard does not record the output with the exec reporter.
+	// Output is still returned to callers that need to parse it.
+	OutputDiscard
+)
+
+// ExecOutput sets the output mode of the one-off containers running relayer commands, e.g. queries.
+func ExecOutput(mode OutputMode) RelayerOpt {
 	return func(r *DockerRelayer) {
-		r.pullImage = pull
+		r.execOutput = mode
+	}
+}
+
+// StartOutput sets the output mode of the long-running container started through StartRelayer.
+func StartOutput(mode OutputMode) RelayerOpt {
+	return func(r *DockerRelayer) {
+		r.startOutput = mode
 	}
 }
 
@@ -45,3 +196,16 @@ func StartupFlags(flags ...string) RelayerOpt {
 		r.extraStartupFlags = flags
 	}
 }
+
+// CommandTimeout overrides how long the relayer command built by the given RelayerCommander method,
+// e.g. "CreateClients" or "GetChannels", may run before it is cancelled. By default, handshakes and flushing packets
+// may run for 10 minutes, queries for 3 minutes, and commands that only touch the relayer's home directory for 1 minute.
+// It can be given multiple times.
+func CommandTimeout(name string, timeout time.Duration) RelayerOpt {
+	return func(r *DockerRelayer) {
+		if r.commandTimeouts == nil {
+			r.commandTimeouts = map[string]time.Duration{}
+		}
+		r.commandTimeouts[name] = timeout
+	}
+}
@@ -113,21 +113,21 @@ func (commander) DockerUser() string {
 	return RlyDefaultUidGid // docker run -it --rm --entrypoint echo ghcr.io/cosmos/relayer "$(id -u):$(id -g)"
 }
 
-func (commander) AddChainConfiguration(containerFilePath, homeDir string) []string {
+func (commander) AddChainConfiguration(containerFilePath, homeDir string) ([]string, error) {
 	return []string{
 		"rly", "chains", "add", "-f", containerFilePath,
 		"--home", homeDir,
-	}
+	}, nil
 }
 
-func (commander) AddKey(chainID, keyName, coinType, homeDir string) []string {
+func (commander) AddKey(chainID, keyName, coinType, homeDir string) ([]string, error) {
 	return []string{
 		"rly", "keys", "add", chainID, keyName,
 		"--coin-type", fmt.Sprint(coinType), "--home", homeDir,
-	}
+	}, nil
 }
 
-func (commander) CreateChannel(pathName string, opts ibc.CreateChannelOptions, homeDir string) []string {
+func (commander) CreateChannel(pathName string, opts ibc.CreateChannelOptions, homeDir string) ([]string, error) {
 	return []string{
 		"rly", "tx", "channel", pathName,
 		"--src-port", opts.SourcePortName,
@@ -136,14 +136,14 @@ func (commander) CreateChannel(pathName string, opts ibc.CreateChannelOptions, h
 		"--version", opts.Version,
 
 		"--home", homeDir,
-	}
+	}, nil
 }
 
-func (commander) CreateClients(pathName string, opts ibc.CreateClientOptions, homeDir string) []string {
+func (commander) CreateClients(pathName string, opts ibc.CreateClientOptions, homeDir string) ([]string, error) {
 	return []string{
 		"rly", "tx", "clients", pathName, "--client-tp", opts.TrustingPeriod,
 		"--home", homeDir,
-	}
+	}, nil
 }
 
 // passing a value of 0 for customeClientTrustingPeriod will use default
@@ -154,14 +154,14 @@ func (commander) CreateClient(pathName, homeDir, customeClientTrustingPeriod str
 	}
 }
 
-func (commander) CreateConnections(pathName string, homeDir string) []string {
+func (commander) CreateConnections(pathName string, homeDir string) ([]string, error) {
 	return []string{
 		"rly", "tx", "connection", pathName,
 		"--home", homeDir,
-	}
+	}, nil
 }
 
-func (commander) Flush(pathName, channelID, homeDir string) []string {
+func (commander) Flush(pathName, channelID, homeDir string) ([]string, error) {
 	cmd := []string{"rly", "tx", "flush"}
 	if pathName != "" {
 		cmd = append(cmd, pathName)
@@ -170,47 +170,47 @@ func (commander) Flush(pathName, channelID, homeDir string) []string {
 		}
 	}
 	cmd = append(cmd, "--home", homeDir)
-	return cmd
+	return cmd, nil
 }
 
-func (commander) GeneratePath(srcChainID, dstChainID, pathName, homeDir string) []string {
+func (commander) GeneratePath(srcChainID, dstChainID, pathName, homeDir string) ([]string, error) {
 	return []string{
 		"rly", "paths", "new", srcChainID, dstChainID, pathName,
 		"--home", homeDir,
-	}
+	}, nil
 }
 
-func (commander) UpdatePath(pathName, homeDir string, filter ibc.ChannelFilter) []string {
+func (commander) UpdatePath(pathName, homeDir string, filter ibc.ChannelFilter) ([]string, error) {
 	return []string{
 		"rly", "paths", "update", pathName,
 		"--home", homeDir,
 		"--filter-rule", filter.Rule,
 		"--filter-channels", strings.Join(filter.ChannelList, ","),
-	}
+	}, nil
 }
 
-func (commander) GetChannels(chainID, homeDir string) []string {
+func (commander) GetChannels(chainID, homeDir string) ([]string, error) {
 	return []string{
 		"rly", "q", "channels", chainID,
 		"--home", homeDir,
-	}
+	}, nil
 }
 
-func (commander) GetConnections(chainID, homeDir string) []string {
+func (commander) GetConnections(chainID, homeDir string) ([]string, error) {
 	return []string{
 		"rly", "q", "connections", chainID,
 		"--home", homeDir,
-	}
+	}, nil
 }
 
-func (commander) GetClients(chainID, homeDir string) []string {
+func (commander) GetClients(chainID, homeDir string) ([]string, error) {
 	return []string{
 		"rly", "q", "clients", chainID,
 		"--home", homeDir,
-	}
+	}, nil
 }
 
-func (commander) LinkPath(pathName, homeDir string, channelOpts ibc.CreateChannelOptions, clientOpt ibc.CreateClientOptions) []string {
+func (commander) LinkPath(pathName, homeDir string, channelOpts ibc.CreateChannelOptions, clientOpt ibc.CreateClientOptions) ([]string, error) {
 	return []string{
 		"rly", "tx", "link", pathName,
 		"--src-port", channelOpts.SourcePortName,
@@ -221,31 +221,31 @@ func (commander) LinkPath(pathName, homeDir string, channelOpts ibc.CreateChanne
 		"--debug",
 
 		"--home", homeDir,
-	}
+	}, nil
 }
 
-func (commander) RestoreKey(chainID, keyName, coinType, mnemonic, homeDir string) []string {
+func (commander) RestoreKey(chainID, keyName, coinType, mnemonic, homeDir string) ([]string, error) {
 	return []string{
 		"rly", "keys", "restore", chainID, keyName, mnemonic,
 		"--coin-type", fmt.Sprint(coinType), "--home", homeDir,
-	}
+	}, nil
 }
 
-func (c commander) StartRelayer(homeDir string, pathNames ...string) []string {
+func (c commander) StartRelayer(homeDir string, pathNames ...string) ([]string, error) {
 	cmd := []string{
 		"rly", "start", "--debug",
 		"--home", homeDir,
 	}
 	cmd = append(cmd, c.extraStartFlags...)
 	cmd = append(cmd, pathNames...)
-	return cmd
+	return cmd, nil
 }
 
-func (commander) UpdateClients(pathName, homeDir string) []string {
+func (commander) UpdateClients(pathName, homeDir string) ([]string, error) {
 	return []string{
 		"rly", "tx", "update-clients", pathName,
 		"--home", homeDir,
-	}
+	}, nil
 }
 
 func (commander) ConfigContent(ctx context.Context, cfg ibc.ChainConfig, keyName, rpcAddr, grpcAddr string) ([]byte, error) {
@@ -0,0 +1,59 @@
+package relayer
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+)
+
+// WalletAddresses returns the address of the relayer's wallet on each of the given chains, keyed by chain ID.
+// An error is returned if the relayer has no wallet for one of the chains.
+func WalletAddresses(r ibc.Relayer, chainIDs ...string) (map[string]string, error) {
+	addresses := make(map[string]string, len(chainIDs))
+	for _, chainID := range chainIDs {
+		wallet, ok := r.GetWallet(chainID)
+		if !ok {
+			return nil, fmt.Errorf("no relayer wallet for chain %s", chainID)
+		}
+		addresses[chainID] = wallet.FormattedAddress()
+	}
+	return addresses, nil
+}
+
+// CheckAddressPrefix verifies that addr is a valid bech32 address with the given human readable prefix.
+func CheckAddressPrefix(addr, prefix string) error {
+	hrp, _, err := bech32.DecodeAndConvert(addr)
+	if err != nil {
+		return fmt.Errorf("decoding address %q: %w", addr, err)
+	}
+	if hrp != prefix {
+		return fmt.Errorf("address %s has prefix %s, expected %s", addr, hrp, prefix)
+	}
+	return nil
+}
+
+// CheckSameAccount verifies that the given bech32 addresses, keyed by chain ID, all belong to the same account,
+// i.e. that they only differ by their prefix. This catches wallets restored with the wrong key or funded
+// at an address meant for another chain.
+func CheckSameAccount(addresses map[string]string) error {
+	var (
+		wantChainID string
+		want        []byte
+	)
+	for chainID, addr := range addresses {
+		_, bz, err := bech32.DecodeAndConvert(addr)
+		if err != nil {
+			return fmt.Errorf("decoding address %s for chain %s: %w", addr, chainID, err)
+		}
+		if want == nil {
+			wantChainID, want = chainID, bz
+			continue
+		}
+		if string(bz) != string(want) {
+			return fmt.Errorf("address %s for chain %s does not match address %s for chain %s",
+				addr, chainID, addresses[wantChainID], wantChainID)
+		}
+	}
+	return nil
+}
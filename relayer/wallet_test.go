@@ -0,0 +1,118 @@
+package relayer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// mockWalletRelayer returns the configured wallets from GetWallet.
+// Calling any other Relayer method panics.
+type mockWalletRelayer struct {
+	ibc.Relayer
+
+	wallets map[string]ibc.Wallet
+}
+
+func (r mockWalletRelayer) GetWallet(chainID string) (ibc.Wallet, bool) {
+	w, ok := r.wallets[chainID]
+	return w, ok
+}
+
+// mockWallet only implements FormattedAddress.
+type mockWallet struct {
+	ibc.Wallet
+
+	address string
+}
+
+func (w mockWallet) FormattedAddress() string {
+	return w.address
+}
+
+func TestWalletAddresses(t *testing.T) {
+	const mnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+	derived, err := hd.Secp256k1.Derive()(mnemonic, "", hd.CreateHDPath(118, 0, 0).String())
+	require.NoError(t, err)
+	addr := hd.Secp256k1.Generate()(derived).PubKey().Address()
+
+	cosmosAddr, err := bech32.ConvertAndEncode("cosmos", addr)
+	require.NoError(t, err)
+	osmoAddr, err := bech32.ConvertAndEncode("osmo", addr)
+	require.NoError(t, err)
+	otherAddr, err := bech32.ConvertAndEncode("osmo", make([]byte, len(addr)))
+	require.NoError(t, err)
+
+	r := mockWalletRelayer{wallets: map[string]ibc.Wallet{
+		"gaia-1":    mockWallet{address: cosmosAddr},
+		"osmosis-1": mockWallet{address: osmoAddr},
+	}}
+
+	addresses, err := WalletAddresses(r, "gaia-1", "osmosis-1")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"gaia-1": cosmosAddr, "osmosis-1": osmoAddr}, addresses)
+	require.NoError(t, CheckSameAccount(addresses))
+
+	addresses["osmosis-1"] = otherAddr
+	require.ErrorContains(t, CheckSameAccount(addresses), "does not match")
+
+	_, err = WalletAddresses(r, "juno-1")
+	require.ErrorContains(t, err, "no relayer wallet for chain juno-1")
+}
+
+// addKeyCommander adds keys whose address is the output of the command.
+// Calling any other RelayerCommander method panics.
+type addKeyCommander struct {
+	RelayerCommander
+}
+
+func (addKeyCommander) Name() string {
+	return "fake"
+}
+
+func (addKeyCommander) AddKey(chainID, keyName, coinType, homeDir string) ([]string, error) {
+	return []string{"fake", "keys", "add", chainID, keyName}, nil
+}
+
+func (addKeyCommander) ParseAddKeyOutput(stdout, stderr string) (ibc.Wallet, error) {
+	return mockWallet{address: stdout}, nil
+}
+
+func TestAddKeyAddressPrefix(t *testing.T) {
+	ctx := context.Background()
+
+	addr := make([]byte, 20)
+	cosmosAddr, err := bech32.ConvertAndEncode("cosmos", addr)
+	require.NoError(t, err)
+	osmoAddr, err := bech32.ConvertAndEncode("osmo", addr)
+	require.NoError(t, err)
+
+	require.NoError(t, CheckAddressPrefix(cosmosAddr, "cosmos"))
+	require.ErrorContains(t, CheckAddressPrefix(osmoAddr, "cosmos"), "has prefix osmo, expected cosmos")
+	require.ErrorContains(t, CheckAddressPrefix("cosmos1invalid", "cosmos"), "decoding address")
+
+	output := cosmosAddr
+	exec := func(context.Context, []string, []string) ibc.RelayerExecResult {
+		return ibc.RelayerExecResult{Stdout: []byte(output)}
+	}
+	r := NewDockerRelayerWithExecutor(zap.NewNop(), t.Name(), addKeyCommander{}, exec)
+	r.recordBech32Prefix("gaia-1", "cosmos")
+
+	wallet, err := r.AddKey(ctx, ibc.NopRelayerExecReporter{}, "gaia-1", "relayer", "118")
+	require.NoError(t, err)
+	require.Equal(t, cosmosAddr, wallet.FormattedAddress())
+
+	output = osmoAddr
+	_, err = r.AddKey(ctx, ibc.NopRelayerExecReporter{}, "gaia-1", "relayer", "118")
+	require.ErrorContains(t, err, "key relayer added for chain gaia-1: address "+osmoAddr+" has prefix osmo, expected cosmos")
+
+	// Chains that were not configured through AddChainConfiguration are not validated.
+	_, err = r.AddKey(ctx, ibc.NopRelayerExecReporter{}, "osmosis-1", "relayer", "118")
+	require.NoError(t, err)
+}
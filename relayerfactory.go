@@ -88,12 +88,12 @@ func (f *builtinRelayerFactory) setRelayerVersion(di ibc.DockerImage) {
 func (f *builtinRelayerFactory) Name() string {
 	switch f.impl {
 	case ibc.CosmosRly:
-		if f.version == "" {
+		if f.version != "" {
 			return "rly@" + f.version
 		}
 		return "rly@" + rly.DefaultContainerVersion
 	case ibc.Hermes:
-		if f.version == "" {
+		if f.version != "" {
 			return "hermes@" + f.version
 		}
 		return "hermes@" + hermes.DefaultContainerVersion
@@ -109,8 +109,7 @@ func (f builtinRelayerFactory) Capabilities() map[relayer.Capability]bool {
 	case ibc.CosmosRly:
 		return rly.Capabilities()
 	case ibc.Hermes:
-		// TODO: specify capability for hermes.
-		return rly.Capabilities()
+		return hermes.Capabilities()
 	default:
 		panic(fmt.Errorf("RelayerImplementation %v unknown", f.impl))
 	}
@@ -0,0 +1,41 @@
+package interchaintest_test
+
+import (
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v8"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/relayer"
+	"github.com/strangelove-ventures/interchaintest/v8/relayer/hermes"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestBuiltinRelayerFactory_HermesVersionsSideBySide(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	client, network := interchaintest.DockerSetup(t)
+
+	const oldVersion = "1.4.0"
+	oldFactory := interchaintest.NewBuiltinRelayerFactory(
+		ibc.Hermes, zaptest.NewLogger(t),
+		relayer.CustomDockerImage("ghcr.io/informalsystems/hermes", oldVersion, "1001:1001"),
+	)
+	newFactory := interchaintest.NewBuiltinRelayerFactory(ibc.Hermes, zaptest.NewLogger(t))
+
+	oldRelayer := oldFactory.Build(t, client, network).(*hermes.Relayer)
+	newRelayer := newFactory.Build(t, client, network).(*hermes.Relayer)
+
+	require.Equal(t, oldVersion, oldRelayer.ContainerImage().Version)
+	require.Equal(t, hermes.DefaultContainerVersion, newRelayer.ContainerImage().Version)
+
+	require.Equal(t, "hermes@"+oldVersion, oldFactory.Name())
+	require.Equal(t, "hermes@"+hermes.DefaultContainerVersion, newFactory.Name())
+
+	// Each relayer owns its own home directory volume.
+	require.NotEqual(t, oldRelayer.Bind(), newRelayer.Bind())
+}
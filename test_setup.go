@@ -30,6 +30,34 @@ func KeepDockerVolumesOnFailure(b bool) {
 	dockerutil.KeepVolumesOnFailure = b
 }
 
+// KeepDockerResourcesOnFailure sets whether the containers, volumes and networks associated with
+// a particular test are retained following a test failure, for manual inspection.
+// They are still deleted when the test passes.
+//
+// The value is false by default, but can be initialized to true by setting the
+// environment variable IBCTEST_SKIP_CLEANUP to a non-empty value.
+func KeepDockerResourcesOnFailure(b bool) {
+	dockerutil.KeepResourcesOnFailure = b
+}
+
+// KeepDockerNetworks sets whether the networks associated with a particular test are retained
+// rather than removed during cleanup, e.g. because they are shared with externally managed resources.
+// Containers and volumes are still removed.
+//
+// The value is false by default, but can be initialized to true by setting the
+// environment variable IBCTEST_SKIP_NETWORK_CLEANUP to a non-empty value.
+func KeepDockerNetworks(b bool) {
+	dockerutil.KeepNetworks = b
+}
+
+// DockerNetworkCleanupGracePeriod sets how long cleanup keeps retrying to remove a network that still has
+// active endpoints, e.g. because containers have not fully detached from it after being stopped.
+//
+// The default is 10 seconds.
+func DockerNetworkCleanupGracePeriod(d time.Duration) {
+	dockerutil.NetworkCleanupGracePeriod = d
+}
+
 // DockerSetup returns a new Docker Client and the ID of a configured network, associated with t.
 //
 // If any part of the setup fails, t.Fatal is called.
@@ -38,6 +66,18 @@ func DockerSetup(t dockerutil.DockerSetupTestingT) (*client.Client, string) {
 	return dockerutil.DockerSetup(t)
 }
 
+// DockerSetupOptions optionally configures DockerSetupWithOptions.
+type DockerSetupOptions = dockerutil.DockerSetupOptions
+
+// DockerSetupWithOptions is like DockerSetup, but allows additional configuration of the created resources,
+// such as custom labels on the network.
+//
+// If any part of the setup fails, t.Fatal is called.
+func DockerSetupWithOptions(t dockerutil.DockerSetupTestingT, opts DockerSetupOptions) (*client.Client, string) {
+	t.Helper()
+	return dockerutil.DockerSetupWithOptions(t, opts)
+}
+
 // startup both chains
 // creates wallets in the relayer for src and dst chain
 // funds relayer src and dst wallets on respective chain in genesis
@@ -0,0 +1,110 @@
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cosmossdk.io/math"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+)
+
+// roundTripTimeout is how long AssertTransferRoundTrip waits at most for each leg to be reflected in the balances
+// after the packets have been flushed.
+const roundTripTimeout = time.Minute
+
+// AssertTransferRoundTrip sends amount of chainA's native denom from userA to userB over the transfer channel
+// between the chains, then sends it back, flushing the packets of each leg with the relayer.
+// The path must have chainA as its source chain, and the relayer should not be running.
+//
+// An error is returned unless userB's balance of the IBC denom returns to its starting value and userA's balance
+// of the native denom returns to its starting value, less at most maxFee paid in transaction fees.
+func AssertTransferRoundTrip(
+	ctx context.Context,
+	rep ibc.RelayerExecReporter,
+	r ibc.Relayer,
+	pathName string,
+	chainA, chainB ibc.Chain,
+	userA, userB ibc.Wallet,
+	amount, maxFee math.Int,
+) error {
+	chainACfg, chainBCfg := chainA.Config(), chainB.Config()
+	ch, err := ibc.GetTransferChannel(ctx, r, rep, chainACfg.ChainID, chainBCfg.ChainID)
+	if err != nil {
+		return err
+	}
+	denomA := chainACfg.Denom
+	denomB := ibc.IBCDenom(ch.Counterparty.PortID, ch.Counterparty.ChannelID, denomA)
+
+	startA, err := chainA.GetBalance(ctx, userA.FormattedAddress(), denomA)
+	if err != nil {
+		return fmt.Errorf("failed to get starting balance on %s: %w", chainACfg.ChainID, err)
+	}
+	startB, err := chainB.GetBalance(ctx, userB.FormattedAddress(), denomB)
+	if err != nil {
+		return fmt.Errorf("failed to get starting balance on %s: %w", chainBCfg.ChainID, err)
+	}
+
+	if _, err := ibc.SendIBCTransfer(ctx, r, chainA, ch.ChannelID, userA.KeyName(), ibc.WalletAmount{
+		Address: userB.FormattedAddress(),
+		Denom:   denomA,
+		Amount:  amount,
+	}, ibc.TransferOptions{}); err != nil {
+		return fmt.Errorf("failed to send transfer from %s: %w", chainACfg.ChainID, err)
+	}
+	if err := r.Flush(ctx, rep, pathName, ch.ChannelID); err != nil {
+		return fmt.Errorf("failed to relay transfer from %s: %w", chainACfg.ChainID, err)
+	}
+	if err := waitForBalance(ctx, chainB, userB.FormattedAddress(), denomB, func(bal math.Int) bool {
+		return bal.Equal(startB.Add(amount))
+	}); err != nil {
+		return fmt.Errorf("transfer from %s not received on %s: %w", chainACfg.ChainID, chainBCfg.ChainID, err)
+	}
+
+	if _, err := ibc.SendIBCTransfer(ctx, r, chainB, ch.Counterparty.ChannelID, userB.KeyName(), ibc.WalletAmount{
+		Address: userA.FormattedAddress(),
+		Denom:   denomB,
+		Amount:  amount,
+	}, ibc.TransferOptions{}); err != nil {
+		return fmt.Errorf("failed to send transfer from %s: %w", chainBCfg.ChainID, err)
+	}
+	if err := r.Flush(ctx, rep, pathName, ch.ChannelID); err != nil {
+		return fmt.Errorf("failed to relay transfer from %s: %w", chainBCfg.ChainID, err)
+	}
+	if err := waitForBalance(ctx, chainB, userB.FormattedAddress(), denomB, func(bal math.Int) bool {
+		return bal.Equal(startB)
+	}); err != nil {
+		return fmt.Errorf("balance of %s on %s did not return to %s: %w", denomB, chainBCfg.ChainID, startB, err)
+	}
+
+	minA := startA.Sub(maxFee)
+	if err := waitForBalance(ctx, chainA, userA.FormattedAddress(), denomA, func(bal math.Int) bool {
+		return bal.GTE(minA) && bal.LTE(startA)
+	}); err != nil {
+		return fmt.Errorf("balance of %s on %s did not return to between %s and %s: %w", denomA, chainACfg.ChainID, minA, startA, err)
+	}
+	return nil
+}
+
+// waitForBalance polls the balance of the address until it satisfies ok, roundTripTimeout elapses,
+// or ctx is done.
+func waitForBalance(ctx context.Context, chain ibc.Chain, address, denom string, ok func(math.Int) bool) error {
+	ctx, cancel := context.WithTimeout(ctx, roundTripTimeout)
+	defer cancel()
+
+	for {
+		bal, err := chain.GetBalance(ctx, address, denom)
+		if err != nil {
+			return err
+		}
+		if ok(bal) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("last balance %s: %w", bal, ctx.Err())
+		case <-time.After(time.Second):
+		}
+	}
+}
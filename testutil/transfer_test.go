@@ -0,0 +1,128 @@
+package testutil
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cosmossdk.io/math"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+// transferLedger holds the balances of the mock chains and the transfers awaiting relaying.
+type transferLedger struct {
+	balances map[string]math.Int
+	pending  []func()
+	fee      math.Int
+}
+
+func (l *transferLedger) add(chainID, address, denom string, amount math.Int) {
+	key := chainID + "/" + address + "/" + denom
+	bal, ok := l.balances[key]
+	if !ok {
+		bal = math.ZeroInt()
+	}
+	l.balances[key] = bal.Add(amount)
+}
+
+type mockTransferChain struct {
+	ibc.Chain
+	cfg    ibc.ChainConfig
+	ledger *transferLedger
+	// counterparty and recvDenom describe where transfers sent from this chain are received.
+	counterparty *mockTransferChain
+	recvDenom    func(denom string) string
+}
+
+func (c *mockTransferChain) Config() ibc.ChainConfig { return c.cfg }
+
+func (c *mockTransferChain) GetBalance(_ context.Context, address, denom string) (math.Int, error) {
+	bal, ok := c.ledger.balances[c.cfg.ChainID+"/"+address+"/"+denom]
+	if !ok {
+		return math.ZeroInt(), nil
+	}
+	return bal, nil
+}
+
+func (c *mockTransferChain) SendIBCTransfer(_ context.Context, _, keyName string, amount ibc.WalletAmount, _ ibc.TransferOptions) (ibc.Tx, error) {
+	c.ledger.add(c.cfg.ChainID, keyName, amount.Denom, amount.Amount.Neg())
+	c.ledger.add(c.cfg.ChainID, keyName, c.cfg.Denom, c.ledger.fee.Neg())
+	c.ledger.pending = append(c.ledger.pending, func() {
+		c.ledger.add(c.counterparty.cfg.ChainID, amount.Address, c.recvDenom(amount.Denom), amount.Amount)
+	})
+	return ibc.Tx{}, nil
+}
+
+type mockTransferRelayer struct {
+	ibc.Relayer
+	ledger  *transferLedger
+	flushed []string
+}
+
+func (r *mockTransferRelayer) GetClients(_ context.Context, _ ibc.RelayerExecReporter, _ string) (ibc.ClientOutputs, error) {
+	return ibc.ClientOutputs{{ClientID: "07-tendermint-0", ClientState: ibc.ClientState{ChainID: "osmosis-1"}}}, nil
+}
+
+func (r *mockTransferRelayer) GetConnections(_ context.Context, _ ibc.RelayerExecReporter, _ string) (ibc.ConnectionOutputs, error) {
+	return ibc.ConnectionOutputs{{ID: "connection-0", ClientID: "07-tendermint-0"}}, nil
+}
+
+func (r *mockTransferRelayer) GetChannels(_ context.Context, _ ibc.RelayerExecReporter, _ string) ([]ibc.ChannelOutput, error) {
+	return []ibc.ChannelOutput{{
+		PortID:         "transfer",
+		ChannelID:      "channel-0",
+		ConnectionHops: []string{"connection-0"},
+		Counterparty:   ibc.ChannelCounterparty{PortID: "transfer", ChannelID: "channel-3"},
+	}}, nil
+}
+
+func (r *mockTransferRelayer) Flush(_ context.Context, _ ibc.RelayerExecReporter, pathName, channelID string) error {
+	r.flushed = append(r.flushed, pathName+"/"+channelID)
+	for _, relay := range r.ledger.pending {
+		relay()
+	}
+	r.ledger.pending = nil
+	return nil
+}
+
+type mockWallet struct {
+	ibc.Wallet
+	address string
+}
+
+func (w mockWallet) KeyName() string          { return w.address }
+func (w mockWallet) FormattedAddress() string { return w.address }
+
+func TestAssertTransferRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	ibcDenom := ibc.IBCDenom("transfer", "channel-3", "uatom")
+
+	newChains := func(fee int64) (*transferLedger, *mockTransferChain, *mockTransferChain) {
+		ledger := &transferLedger{balances: map[string]math.Int{}, fee: math.NewInt(fee)}
+		gaia := &mockTransferChain{cfg: ibc.ChainConfig{ChainID: "gaia-1", Denom: "uatom"}, ledger: ledger}
+		osmosis := &mockTransferChain{cfg: ibc.ChainConfig{ChainID: "osmosis-1", Denom: "uosmo"}, ledger: ledger}
+		gaia.counterparty, osmosis.counterparty = osmosis, gaia
+		gaia.recvDenom = func(string) string { return ibcDenom }
+		osmosis.recvDenom = func(string) string { return "uatom" }
+		ledger.add("gaia-1", "cosmos1a", "uatom", math.NewInt(1_000_000))
+		ledger.add("osmosis-1", "osmo1b", "uosmo", math.NewInt(1_000_000))
+		return ledger, gaia, osmosis
+	}
+	userA, userB := mockWallet{address: "cosmos1a"}, mockWallet{address: "osmo1b"}
+
+	ledger, gaia, osmosis := newChains(500)
+	r := &mockTransferRelayer{ledger: ledger}
+	err := AssertTransferRoundTrip(ctx, ibc.NopRelayerExecReporter{}, r, "p", gaia, osmosis, userA, userB, math.NewInt(10_000), math.NewInt(1_000))
+	require.NoError(t, err)
+	require.Equal(t, []string{"p/channel-0", "p/channel-0"}, r.flushed)
+	require.True(t, ledger.balances["gaia-1/cosmos1a/uatom"].Equal(math.NewInt(999_500)))
+	require.True(t, ledger.balances["osmosis-1/osmo1b/"+ibcDenom].IsZero())
+
+	ledger, gaia, osmosis = newChains(2_000)
+	r = &mockTransferRelayer{ledger: ledger}
+	ctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	err = AssertTransferRoundTrip(ctx, ibc.NopRelayerExecReporter{}, r, "p", gaia, osmosis, userA, userB, math.NewInt(10_000), math.NewInt(1_000))
+	require.ErrorContains(t, err, "balance of uatom on gaia-1 did not return to between 999000 and 1000000")
+}